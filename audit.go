@@ -0,0 +1,66 @@
+package errortranslator
+
+import (
+	validate "github.com/mbict/go-validate"
+	"golang.org/x/text/language"
+)
+
+// Miss describes a error without translation, reported to the OnMissing hook of the options.
+type Miss struct {
+	Field  string
+	Err    error
+	Locale language.Tag
+}
+
+// Finding is a field and error combination found by Audit
+type Finding struct {
+	Field string
+	Err   error
+
+	// Locale is the language of a *LocalizedTranslator, language.Und for other translators.
+	Locale language.Tag
+
+	// Default is true when the error is translated with a default translation, false when there is no translation.
+	Default bool
+}
+
+// Audit translates every error for every field and returns the combinations that have no translation or that are
+// translated with a default translation. Findings are in the order of the fields and errors.
+// Use it in a unit test to prevent untranslated errors:
+//
+//	findings := errortranslator.Audit(translator, errortranslator.FieldOrder(User{}), []error{validate.ErrRequired})
+//	if len(findings) > 0 {
+//		t.Errorf("missing translations: %v", findings)
+//	}
+func Audit(translator DetailTranslator, fields []string, errs []error) []Finding {
+	locale := language.Und
+	if l, ok := translator.(interface{ Locale() language.Tag }); ok {
+		locale = l.Locale()
+	}
+
+	var findings []Finding
+	for _, field := range fields {
+		for _, err := range errs {
+			translations, _ := translator.TranslateDetails(validate.ErrorMap{field: validate.Errors{err}})
+
+			t := translations[field]
+			switch {
+			case len(t) == 0:
+				findings = append(findings, Finding{Field: field, Err: err, Locale: locale})
+			case t[0].Code == DefaultCode:
+				findings = append(findings, Finding{Field: field, Err: err, Locale: locale, Default: true})
+			}
+		}
+	}
+	return findings
+}
+
+// AuditLocales audits every registered language of the LocaleTranslator, see Audit.
+// Missing translations that are provided by a parent or the default language are not reported.
+func AuditLocales(lt *LocaleTranslator, fields []string, errs []error) []Finding {
+	var findings []Finding
+	for _, locale := range lt.Locales() {
+		findings = append(findings, Audit(lt.ForLocale(locale), fields, errs)...)
+	}
+	return findings
+}
@@ -0,0 +1,86 @@
+package errortranslator_test
+
+import (
+	errortranslator "github.com/mbict/go-errortranslator"
+	validate "github.com/mbict/go-validate"
+	"golang.org/x/text/language"
+	. "gopkg.in/check.v1"
+)
+
+type AuditSuite struct{}
+
+var _ = Suite(&AuditSuite{})
+
+func (s *AuditSuite) TestOnMissing(c *C) {
+	var misses []errortranslator.Miss
+	t := errortranslator.New().
+		AddTranslation("A", validate.ErrRequired, "A required translate").
+		WithOptions(errortranslator.Options{OnMissing: func(m errortranslator.Miss) {
+			misses = append(misses, m)
+		}})
+
+	result, ok := t.Translate(validate.ErrorMap{
+		"A": validate.Errors{validate.ErrRequired, validate.ErrMin},
+	})
+
+	c.Assert(ok, Equals, true)
+	c.Assert(result, DeepEquals, map[string]string{"A": "A required translate"})
+	c.Assert(misses, DeepEquals, []errortranslator.Miss{
+		{Field: "A", Err: validate.ErrMin, Locale: language.Und},
+	})
+}
+
+func (s *AuditSuite) TestOnMissingLocale(c *C) {
+	var misses []errortranslator.Miss
+
+	lt := errortranslator.NewLocaleTranslator(language.English)
+	lt.Locale(language.English).AddTranslation("A", validate.ErrRequired, "A required translate")
+	lt.Locale(language.Dutch)
+	lt.SetOptions(errortranslator.Options{OnMissing: func(m errortranslator.Miss) {
+		misses = append(misses, m)
+	}})
+
+	_, ok := lt.Translate(language.Dutch, validate.ErrorMap{"B": validate.Errors{validate.ErrMax}})
+
+	c.Assert(ok, Equals, false)
+	c.Assert(misses, DeepEquals, []errortranslator.Miss{
+		{Field: "B", Err: validate.ErrMax, Locale: language.Dutch},
+	})
+}
+
+func (s *AuditSuite) TestAudit(c *C) {
+	ft := errortranslator.New().
+		AddTranslation("Email", validate.ErrRequired, "Please enter your email").
+		SetDefaultTranslation("Email", "Email is invalid").
+		AddTranslation("Name", validate.ErrRequired, "Please enter your name").
+		SetFallbackTranslation(validate.ErrMin, "{field} is too short")
+
+	findings := errortranslator.Audit(ft, []string{"Email", "Name"}, []error{validate.ErrRequired, validate.ErrMin, validate.ErrMax})
+
+	c.Assert(findings, DeepEquals, []errortranslator.Finding{
+		{Field: "Email", Err: validate.ErrMin, Locale: language.Und, Default: true},
+		{Field: "Email", Err: validate.ErrMax, Locale: language.Und, Default: true},
+		{Field: "Name", Err: validate.ErrMax, Locale: language.Und},
+	})
+
+	ft.SetFallbackDefaultTranslation("{field} is invalid")
+	findings = errortranslator.Audit(ft, []string{"Name"}, []error{validate.ErrMax})
+
+	c.Assert(findings, DeepEquals, []errortranslator.Finding{
+		{Field: "Name", Err: validate.ErrMax, Locale: language.Und, Default: true},
+	})
+}
+
+func (s *AuditSuite) TestAuditLocales(c *C) {
+	lt := errortranslator.NewLocaleTranslator(language.English)
+	lt.Locale(language.English).
+		SetFallbackTranslation(validate.ErrRequired, "{field} is required")
+	lt.Locale(language.Dutch).
+		AddTranslation("Name", validate.ErrMin, "{field} is te kort")
+
+	findings := errortranslator.AuditLocales(lt, []string{"Name"}, []error{validate.ErrRequired, validate.ErrMin})
+
+	c.Assert(findings, DeepEquals, []errortranslator.Finding{
+		{Field: "Name", Err: validate.ErrMin, Locale: language.English},
+	})
+}
@@ -0,0 +1,194 @@
+package errortranslator
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+var (
+	// ErrUnknownErrorCode is returned when a catalog refers to a error code that is not registered.
+	ErrUnknownErrorCode = errors.New("unknown error code")
+
+	// ErrUnknownCatalogFormat is returned when the catalog format cannot be determined from the file extension.
+	ErrUnknownCatalogFormat = errors.New("unknown catalog format")
+)
+
+// catalogLoaders are the loaders of the supported catalog formats by file extension
+var catalogLoaders = map[string]func(fsys fs.FS, name string) (FieldErrorTranslator, error){
+	".json": LoadJSON,
+	".yaml": LoadYAML,
+	".yml":  LoadYAML,
+	".toml": LoadTOML,
+	".po":   LoadPO,
+}
+
+// CatalogError describes a error in a catalog file, Line and Column point to the entry that caused the error.
+// Line and Column are zero when the position is unknown.
+type CatalogError struct {
+	File   string
+	Line   int
+	Column int
+	Err    error
+}
+
+func (e *CatalogError) Error() string {
+	if e.Line == 0 {
+		return fmt.Sprintf("%s: %v", e.File, e.Err)
+	}
+	return fmt.Sprintf("%s:%d:%d: %v", e.File, e.Line, e.Column, e.Err)
+}
+
+func (e *CatalogError) Unwrap() error {
+	return e.Err
+}
+
+// LoadCatalog reads a catalog file into a FieldErrorTranslator, the format is selected by the file extension:
+// .json, .yaml, .yml, .toml or .po (see LoadPO)
+//
+// A catalog maps field names to a set of error codes with their translation, the empty field name holds the fallback
+// translations and the DefaultCode the default translation of a field. In JSON:
+//
+//	{
+//	  "A": {"required": "A field is required", "default": "A field has a error"},
+//	  "": {"required": "This is a required field", "default": "There is a unknown error"}
+//	}
+//
+// The error codes are resolved with ErrorForCode, unknown codes and malformed messages are reported as a CatalogError.
+func LoadCatalog(fsys fs.FS, name string) (FieldErrorTranslator, error) {
+	if load, ok := catalogLoaders[strings.ToLower(path.Ext(name))]; ok {
+		return load(fsys, name)
+	}
+	return nil, &CatalogError{File: name, Err: ErrUnknownCatalogFormat}
+}
+
+// IsCatalogFile reports if the file has the extension of a catalog format supported by LoadCatalog.
+// Hidden files, with a name that starts with a dot, are not catalog files.
+func IsCatalogFile(name string) bool {
+	name = path.Base(name)
+	_, ok := catalogLoaders[strings.ToLower(path.Ext(name))]
+	return ok && !strings.HasPrefix(name, ".")
+}
+
+// LoadCatalogFiles reads the catalog files into a FieldErrorTranslator per language, the file name without extension
+// is the language tag: `en.json`, `nl.yaml` and `nl-BE.po`. A invalid language tag or a language that is loaded from
+// more than one file is reported as a CatalogError.
+func LoadCatalogFiles(fsys fs.FS, names ...string) (map[language.Tag]FieldErrorTranslator, error) {
+	catalogs := make(map[language.Tag]FieldErrorTranslator, len(names))
+	files := make(map[language.Tag]string, len(names))
+	for _, name := range names {
+		base := path.Base(name)
+		locale, err := language.Parse(strings.TrimSuffix(base, path.Ext(base)))
+		if err != nil {
+			return nil, &CatalogError{File: name, Err: fmt.Errorf("invalid language in file name: %w", err)}
+		}
+
+		if other, ok := files[locale]; ok {
+			return nil, &CatalogError{File: name, Err: fmt.Errorf("language %s is already loaded from %s", locale, other)}
+		}
+		files[locale] = name
+
+		ft, err := LoadCatalog(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		catalogs[locale] = ft
+	}
+	return catalogs, nil
+}
+
+// LoadCatalogDir reads the catalog files in the directory, see IsCatalogFile and LoadCatalogFiles.
+// Subdirectories are skipped, a directory without catalog files results in a empty map.
+func LoadCatalogDir(fsys fs.FS, dir string) (map[language.Tag]FieldErrorTranslator, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && IsCatalogFile(entry.Name()) {
+			names = append(names, path.Join(dir, entry.Name()))
+		}
+	}
+	return LoadCatalogFiles(fsys, names...)
+}
+
+// LoadJSON reads a JSON catalog file into a FieldErrorTranslator. See LoadCatalog for the format.
+func LoadJSON(fsys fs.FS, name string) (FieldErrorTranslator, error) {
+	return loadCatalog(fsys, name, parseJSONCatalog)
+}
+
+// LoadYAML reads a YAML catalog file into a FieldErrorTranslator. See LoadCatalog for the format.
+func LoadYAML(fsys fs.FS, name string) (FieldErrorTranslator, error) {
+	return loadCatalog(fsys, name, parseYAMLCatalog)
+}
+
+// LoadTOML reads a TOML catalog file into a FieldErrorTranslator. See LoadCatalog for the format, every field is a
+// table. Dotted table names are joined, [address.street] is the field "address.street".
+func LoadTOML(fsys fs.FS, name string) (FieldErrorTranslator, error) {
+	return loadCatalog(fsys, name, parseTOMLCatalog)
+}
+
+func loadCatalog(fsys fs.FS, name string, parse func(b *catalogBuilder, data []byte) error) (FieldErrorTranslator, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &catalogBuilder{name: name, data: data, translator: New()}
+	if err := parse(b, data); err != nil {
+		return nil, err
+	}
+	return b.translator, nil
+}
+
+// catalogBuilder collects the entries of a catalog file into a FieldErrorTranslator
+type catalogBuilder struct {
+	name       string
+	data       []byte
+	translator FieldErrorTranslator
+}
+
+// field registers a field, fields without translations are kept as empty ErrorTranslator.
+func (b *catalogBuilder) field(field string) {
+	if _, ok := b.translator[field]; !ok {
+		b.translator[field] = ErrorTranslator{}
+	}
+}
+
+// add adds a translation, the returned error does not contain the position, the parser adds it.
+func (b *catalogBuilder) add(field string, code string, message string) error {
+	err, ok := ErrorForCode(code)
+	if !ok {
+		return fmt.Errorf("%w %q for field %q", ErrUnknownErrorCode, code, field)
+	}
+
+	if _, ok := b.translator[field][err]; ok {
+		return fmt.Errorf("duplicate translation for field %q and error code %q", field, code)
+	}
+
+	if merr := ValidateMessage(message); merr != nil {
+		return merr
+	}
+
+	b.field(field)
+	b.translator[field][err] = message
+	return nil
+}
+
+// errorAt creates a CatalogError with the line and column of the byte offset
+func (b *catalogBuilder) errorAt(offset int, err error) error {
+	if offset > len(b.data) {
+		offset = len(b.data)
+	}
+
+	lead := b.data[:offset]
+	line := strings.Count(string(lead), "\n") + 1
+	column := offset - strings.LastIndexByte(string(lead), '\n')
+	return &CatalogError{File: b.name, Line: line, Column: column, Err: err}
+}
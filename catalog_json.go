@@ -0,0 +1,98 @@
+package errortranslator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// parseJSONCatalog reads the catalog token by token to keep track of the position of every entry.
+func parseJSONCatalog(b *catalogBuilder, data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	//next reads the next token and returns the offset where the token starts
+	next := func() (json.Token, int, error) {
+		offset := int(dec.InputOffset())
+		token, err := dec.Token()
+		for offset < len(data) && bytes.IndexByte([]byte(" \t\r\n,:"), data[offset]) != -1 {
+			offset++
+		}
+
+		var serr *json.SyntaxError
+		switch {
+		case errors.As(err, &serr):
+			return nil, offset, b.errorAt(int(serr.Offset), err)
+		case err == io.EOF:
+			return nil, offset, b.errorAt(offset, io.ErrUnexpectedEOF)
+		case err != nil:
+			return nil, offset, b.errorAt(offset, err)
+		}
+		return token, offset, nil
+	}
+
+	token, offset, err := next()
+	if err != nil {
+		return err
+	}
+
+	if token != json.Delim('{') {
+		return b.errorAt(offset, errors.New("expected a object with fields"))
+	}
+
+	for dec.More() {
+		token, offset, err = next()
+		if err != nil {
+			return err
+		}
+		field := token.(string)
+
+		token, offset, err = next()
+		if err != nil {
+			return err
+		}
+
+		if token != json.Delim('{') {
+			return b.errorAt(offset, fmt.Errorf("expected a object with translations for field %q", field))
+		}
+		b.field(field)
+
+		for dec.More() {
+			token, offset, err = next()
+			if err != nil {
+				return err
+			}
+			code, codeOffset := token.(string), offset
+
+			token, offset, err = next()
+			if err != nil {
+				return err
+			}
+
+			message, ok := token.(string)
+			if !ok {
+				return b.errorAt(offset, fmt.Errorf("expected a string message for field %q and error code %q", field, code))
+			}
+
+			if err := b.add(field, code, message); err != nil {
+				return b.errorAt(codeOffset, err)
+			}
+		}
+
+		//closing brace of the field
+		if _, _, err := next(); err != nil {
+			return err
+		}
+	}
+
+	//closing brace of the catalog
+	if _, _, err := next(); err != nil {
+		return err
+	}
+
+	if _, err := dec.Token(); err != io.EOF {
+		return b.errorAt(int(dec.InputOffset()), errors.New("unexpected data after the catalog"))
+	}
+	return nil
+}
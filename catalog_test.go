@@ -0,0 +1,218 @@
+package errortranslator_test
+
+import (
+	"errors"
+	"io/fs"
+	"testing/fstest"
+
+	errortranslator "github.com/mbict/go-errortranslator"
+	validate "github.com/mbict/go-validate"
+	"golang.org/x/text/language"
+	. "gopkg.in/check.v1"
+)
+
+type CatalogSuite struct{}
+
+var _ = Suite(&CatalogSuite{})
+
+var catalogFS = fstest.MapFS{
+	"en.json": {Data: []byte(`{
+  "A": {"required": "A field is required", "default": "A field has a error"},
+  "B.1": {"min": "at least {min}"},
+  "C": {},
+  "": {"required": "This is a required field", "custom": "custom error", "default": "There is a unknown error"}
+}`)},
+	"en.yaml": {Data: []byte(`
+A:
+  required: A field is required
+  default: A field has a error
+B.1:
+  min: at least {min}
+C:
+"":
+  required: This is a required field
+  custom: custom error
+  default: There is a unknown error
+`)},
+	"en.toml": {Data: []byte(`
+A = { required = "A field is required", default = "A field has a error" }
+
+["B.1"]
+min = "at least {min}"
+
+[C]
+
+[""]
+required = "This is a required field"
+custom = "custom error"
+default = "There is a unknown error"
+`)},
+	"en.txt": {Data: []byte(`A=required`)},
+}
+
+var expectedCatalog = errortranslator.FieldErrorTranslator{
+	"A": errortranslator.ErrorTranslator{
+		validate.ErrRequired: "A field is required",
+		nil:                  "A field has a error",
+	},
+	"B.1": errortranslator.ErrorTranslator{
+		validate.ErrMin: "at least {min}",
+	},
+	"C": errortranslator.ErrorTranslator{},
+	"": errortranslator.ErrorTranslator{
+		validate.ErrRequired: "This is a required field",
+		errCustom:            "custom error",
+		nil:                  "There is a unknown error",
+	},
+}
+
+func (s *CatalogSuite) TestLoadCatalog(c *C) {
+	for _, name := range []string{"en.json", "en.yaml", "en.toml"} {
+		ft, err := errortranslator.LoadCatalog(catalogFS, name)
+
+		c.Assert(err, IsNil, Commentf(name))
+		c.Assert(ft, DeepEquals, expectedCatalog, Commentf(name))
+	}
+}
+
+func (s *CatalogSuite) TestLoadCatalogUnknownFormat(c *C) {
+	_, err := errortranslator.LoadCatalog(catalogFS, "en.txt")
+
+	c.Assert(err, ErrorMatches, "en.txt: unknown catalog format")
+	c.Assert(errors.Is(err, errortranslator.ErrUnknownCatalogFormat), Equals, true)
+}
+
+func (s *CatalogSuite) TestLoadCatalogNotFound(c *C) {
+	_, err := errortranslator.LoadCatalog(catalogFS, "nl.json")
+
+	c.Assert(errors.Is(err, fs.ErrNotExist), Equals, true)
+}
+
+func (s *CatalogSuite) TestIsCatalogFile(c *C) {
+	tests := []struct {
+		Name     string
+		Expected bool
+	}{
+		{Name: "en.json", Expected: true},
+		{Name: "nl-BE.YAML", Expected: true},
+		{Name: "translations/nl.po", Expected: true},
+		{Name: ".en.json", Expected: false},
+		{Name: "en.txt", Expected: false},
+		{Name: "en", Expected: false},
+	}
+
+	for _, test := range tests {
+		c.Assert(errortranslator.IsCatalogFile(test.Name), Equals, test.Expected, Commentf(test.Name))
+	}
+}
+
+func (s *CatalogSuite) TestLoadCatalogDir(c *C) {
+	fsys := fstest.MapFS{
+		"translations/en.json":    {Data: []byte(`{"": {"required": "required"}}`)},
+		"translations/nl.yaml":    {Data: []byte(`"": {required: verplicht}`)},
+		"translations/README.md":  {Data: []byte(`# translations`)},
+		"translations/.de.json":   {Data: []byte(`invalid`)},
+		"translations/fr/fr.json": {Data: []byte(`invalid`)},
+		"duplicate/en.json":       {Data: []byte(`{}`)},
+		"duplicate/en.toml":       {Data: []byte(``)},
+		"invalid/english.json":    {Data: []byte(`{}`)},
+	}
+
+	catalogs, err := errortranslator.LoadCatalogDir(fsys, "translations")
+	c.Assert(err, IsNil)
+	c.Assert(catalogs, DeepEquals, map[language.Tag]errortranslator.FieldErrorTranslator{
+		language.English: {"": errortranslator.ErrorTranslator{validate.ErrRequired: "required"}},
+		language.Dutch:   {"": errortranslator.ErrorTranslator{validate.ErrRequired: "verplicht"}},
+	})
+
+	_, err = errortranslator.LoadCatalogDir(fsys, "duplicate")
+	c.Assert(err, ErrorMatches, "duplicate/en.toml: language en is already loaded from duplicate/en.json")
+
+	_, err = errortranslator.LoadCatalogDir(fsys, "invalid")
+	c.Assert(err, ErrorMatches, "invalid/english.json: invalid language in file name: .*")
+
+	_, err = errortranslator.LoadCatalogDir(fsys, "missing")
+	c.Assert(errors.Is(err, fs.ErrNotExist), Equals, true)
+}
+
+func (s *CatalogSuite) TestLoadCatalogErrors(c *C) {
+	tests := []struct {
+		Name     string
+		Data     string
+		Expected string
+	}{
+		{
+			Name:     "unknown.json",
+			Data:     "{\n  \"A\": {\n    \"requird\": \"A field is required\"\n  }\n}",
+			Expected: `unknown.json:3:5: unknown error code "requird" for field "A"`,
+		}, {
+			Name:     "malformed.json",
+			Data:     "{\n  \"A\": {\"required\": \"A field is {required\"}\n}",
+			Expected: `malformed.json:2:9: errortranslator: malformed message "A field is {required" at offset 11: unclosed placeholder`,
+		}, {
+			Name:     "duplicate.json",
+			Data:     "{\"A\": {\"required\": \"a\", \"required\": \"b\"}}",
+			Expected: `duplicate.json:1:25: duplicate translation for field "A" and error code "required"`,
+		}, {
+			Name:     "notobject.json",
+			Data:     "{\n  \"A\": \"required\"\n}",
+			Expected: `notobject.json:2:8: expected a object with translations for field "A"`,
+		}, {
+			Name:     "notstring.json",
+			Data:     "{\n  \"A\": {\"required\": 1}\n}",
+			Expected: `notstring.json:2:21: expected a string message for field "A" and error code "required"`,
+		}, {
+			Name:     "syntax.json",
+			Data:     "{\n  \"A\": {\"required\" \"a\"}\n}",
+			Expected: `syntax.json:2:.*: invalid character .*`,
+		}, {
+			Name:     "unexpected.json",
+			Data:     "{\"A\": {}",
+			Expected: `unexpected.json:1:9: unexpected end of JSON input`,
+		}, {
+			Name:     "unknown.yaml",
+			Data:     "A:\n  required: A field is required\n  requird: A field is required\n",
+			Expected: `unknown.yaml:3:3: unknown error code "requird" for field "A"`,
+		}, {
+			Name:     "notmapping.yaml",
+			Data:     "A:\n  - required\n",
+			Expected: `notmapping.yaml:2:3: expected a mapping with translations for field "A"`,
+		}, {
+			Name:     "notstring.yaml",
+			Data:     "A:\n  required: 12\n",
+			Expected: `notstring.yaml:2:13: expected a string message for field "A" and error code "required"`,
+		}, {
+			Name:     "syntax.yaml",
+			Data:     "A:\n  required: \"a\n",
+			Expected: `syntax.yaml: yaml: line 2: .*`,
+		}, {
+			Name:     "unknown.toml",
+			Data:     "[A]\nrequired = \"A field is required\"\n  requird = \"A field is required\"\n",
+			Expected: `unknown.toml:3:3: unknown error code "requird" for field "A"`,
+		}, {
+			Name:     "notstring.toml",
+			Data:     "[A]\nrequired = 1\n",
+			Expected: `notstring.toml:2:1: expected a string message for field "A" and error code "required"`,
+		}, {
+			Name:     "nottable.toml",
+			Data:     "A = \"required\"\n",
+			Expected: `nottable.toml:1:1: expected a table with translations for field "A"`,
+		}, {
+			Name:     "syntax.toml",
+			Data:     "[A]\nrequired = \"a\nmin = \"b\"\n",
+			Expected: `syntax.toml:2:.*`,
+		},
+	}
+
+	for _, test := range tests {
+		_, err := errortranslator.LoadCatalog(fstest.MapFS{test.Name: {Data: []byte(test.Data)}}, test.Name)
+
+		c.Assert(err, ErrorMatches, test.Expected, Commentf(test.Name))
+
+		var cerr *errortranslator.CatalogError
+		c.Assert(errors.As(err, &cerr), Equals, true, Commentf(test.Name))
+	}
+
+	_, err := errortranslator.LoadJSON(fstest.MapFS{"a.json": {Data: []byte(`{"A": {"requird": "a"}}`)}}, "a.json")
+	c.Assert(errors.Is(err, errortranslator.ErrUnknownErrorCode), Equals, true)
+}
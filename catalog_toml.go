@@ -0,0 +1,91 @@
+package errortranslator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2/unstable"
+)
+
+// parseTOMLCatalog reads the catalog expression by expression to keep track of the position of every entry.
+func parseTOMLCatalog(b *catalogBuilder, data []byte) error {
+	p := &unstable.Parser{}
+	p.Reset(data)
+
+	var (
+		field   string
+		inTable bool
+	)
+
+	for p.NextExpression() {
+		expr := p.Expression()
+		switch expr.Kind {
+		case unstable.Table:
+			field, inTable = tomlKey(expr.Key()), true
+			b.field(field)
+		case unstable.KeyValue:
+			if inTable {
+				if err := addTOMLTranslation(b, field, expr); err != nil {
+					return err
+				}
+				continue
+			}
+
+			//top level inline table, A = { required = "..." }
+			inlineField := tomlKey(expr.Key())
+			value := expr.Value()
+			if value.Kind != unstable.InlineTable {
+				return tomlError(b, expr, fmt.Errorf("expected a table with translations for field %q", inlineField))
+			}
+
+			b.field(inlineField)
+			for it := value.Children(); it.Next(); {
+				if err := addTOMLTranslation(b, inlineField, it.Node()); err != nil {
+					return err
+				}
+			}
+		case unstable.ArrayTable:
+			return tomlError(b, expr, errors.New("array tables are not supported"))
+		}
+	}
+
+	if err := p.Error(); err != nil {
+		var perr *unstable.ParserError
+		if errors.As(err, &perr) && perr.Highlight != nil {
+			return b.errorAt(int(p.Range(perr.Highlight).Offset), err)
+		}
+		return b.errorAt(len(data), err)
+	}
+	return nil
+}
+
+func addTOMLTranslation(b *catalogBuilder, field string, kv *unstable.Node) error {
+	code := tomlKey(kv.Key())
+
+	value := kv.Value()
+	if value.Kind != unstable.String {
+		return tomlError(b, kv, fmt.Errorf("expected a string message for field %q and error code %q", field, code))
+	}
+
+	if err := b.add(field, code, string(value.Data)); err != nil {
+		return tomlError(b, kv, err)
+	}
+	return nil
+}
+
+// tomlKey joins a dotted key into a single name
+func tomlKey(it unstable.Iterator) string {
+	var parts []string
+	for it.Next() {
+		parts = append(parts, string(it.Node().Data))
+	}
+	return strings.Join(parts, ".")
+}
+
+// tomlError creates a CatalogError at the position of the key of the table or key value
+func tomlError(b *catalogBuilder, expr *unstable.Node, err error) error {
+	it := expr.Key()
+	it.Next()
+	return b.errorAt(int(it.Node().Raw.Offset), err)
+}
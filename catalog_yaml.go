@@ -0,0 +1,73 @@
+package errortranslator
+
+import (
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// parseYAMLCatalog reads the catalog as a yaml node tree to keep track of the position of every entry.
+func parseYAMLCatalog(b *catalogBuilder, data []byte) error {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return &CatalogError{File: b.name, Err: err}
+	}
+
+	//empty document
+	if len(doc.Content) == 0 {
+		return nil
+	}
+
+	root := resolveYAMLAlias(doc.Content[0])
+	if root.Kind != yaml.MappingNode {
+		return yamlError(b, root, errors.New("expected a mapping with fields"))
+	}
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], resolveYAMLAlias(root.Content[i+1])
+		if key.Kind != yaml.ScalarNode {
+			return yamlError(b, key, errors.New("expected a field name"))
+		}
+		field := key.Value
+
+		//a field without translations
+		if value.Kind == yaml.ScalarNode && value.Tag == "!!null" {
+			b.field(field)
+			continue
+		}
+
+		if value.Kind != yaml.MappingNode {
+			return yamlError(b, value, fmt.Errorf("expected a mapping with translations for field %q", field))
+		}
+		b.field(field)
+
+		for j := 0; j+1 < len(value.Content); j += 2 {
+			codeNode, messageNode := value.Content[j], resolveYAMLAlias(value.Content[j+1])
+			if codeNode.Kind != yaml.ScalarNode {
+				return yamlError(b, codeNode, fmt.Errorf("expected a error code for field %q", field))
+			}
+			code := codeNode.Value
+
+			if messageNode.Kind != yaml.ScalarNode || messageNode.Tag != "!!str" {
+				return yamlError(b, messageNode, fmt.Errorf("expected a string message for field %q and error code %q", field, code))
+			}
+
+			if err := b.add(field, code, messageNode.Value); err != nil {
+				return yamlError(b, codeNode, err)
+			}
+		}
+	}
+	return nil
+}
+
+func resolveYAMLAlias(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	return node
+}
+
+func yamlError(b *catalogBuilder, node *yaml.Node, err error) error {
+	return &CatalogError{File: b.name, Line: node.Line, Column: node.Column, Err: err}
+}
@@ -0,0 +1,114 @@
+// Command catalogcheck compares the translation catalogs of multiple languages and reports missing and extra
+// translations, mismatched placeholders and mismatched plural categories, see errortranslator.CheckCatalogs.
+//
+// The arguments are catalog files or directories with catalog files, the file name without extension is the language
+// tag: `en.json`, `nl.yaml` and `nl-BE.po`. The catalogs are compared with the catalog of the reference language.
+//
+//	go run github.com/mbict/go-errortranslator/cmd/catalogcheck -reference en translations
+//
+// The exit status is 0 when the catalogs are consistent, 1 when inconsistencies are found and 2 when the catalogs
+// cannot be loaded.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	errortranslator "github.com/mbict/go-errortranslator"
+	"golang.org/x/text/language"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("catalogcheck", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	reference := flags.String("reference", "en", "language tag of the reference catalog")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: catalogcheck [-reference tag] file|dir ...")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+
+	ref, err := language.Parse(*reference)
+	if err != nil {
+		fmt.Fprintf(stderr, "catalogcheck: invalid reference language: %v\n", err)
+		return 2
+	}
+
+	catalogs, err := loadCatalogs(flags.Args())
+	if err != nil {
+		fmt.Fprintf(stderr, "catalogcheck: %v\n", err)
+		return 2
+	}
+
+	inconsistencies, err := errortranslator.CheckCatalogs(ref, catalogs)
+	if err != nil {
+		fmt.Fprintf(stderr, "catalogcheck: %v\n", err)
+		return 2
+	}
+
+	for _, inconsistency := range inconsistencies {
+		fmt.Fprintln(stdout, inconsistency)
+	}
+
+	if len(inconsistencies) > 0 {
+		fmt.Fprintf(stderr, "catalogcheck: %d inconsistencies in %d catalogs\n", len(inconsistencies), len(catalogs))
+		return 1
+	}
+	return 0
+}
+
+// loadCatalogs loads the catalog files and the catalog files in the directories
+func loadCatalogs(paths []string) (map[language.Tag]errortranslator.FieldErrorTranslator, error) {
+	catalogs := make(map[language.Tag]errortranslator.FieldErrorTranslator)
+	loaded := make(map[language.Tag]string)
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+
+		var (
+			dir   = path
+			found map[language.Tag]errortranslator.FieldErrorTranslator
+		)
+		if info.IsDir() {
+			found, err = errortranslator.LoadCatalogDir(os.DirFS(dir), ".")
+		} else {
+			dir = filepath.Dir(path)
+			found, err = errortranslator.LoadCatalogFiles(os.DirFS(dir), filepath.Base(path))
+		}
+		if err != nil {
+			//report the file relative to the working directory instead of the catalog directory
+			var catalogErr *errortranslator.CatalogError
+			if errors.As(err, &catalogErr) {
+				catalogErr.File = filepath.Join(dir, filepath.FromSlash(catalogErr.File))
+			}
+			return nil, err
+		}
+
+		for locale, ft := range found {
+			if other, ok := loaded[locale]; ok {
+				return nil, &errortranslator.CatalogError{File: path, Err: fmt.Errorf("language %s is already loaded from %s", locale, other)}
+			}
+			loaded[locale] = path
+			catalogs[locale] = ft
+		}
+	}
+	return catalogs, nil
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type CatalogCheckSuite struct{}
+
+var _ = Suite(&CatalogCheckSuite{})
+
+func writeCatalogs(c *C, files map[string]string) string {
+	dir := c.MkDir()
+	for name, content := range files {
+		c.Assert(os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644), IsNil)
+	}
+	return dir
+}
+
+func (s *CatalogCheckSuite) TestConsistent(c *C) {
+	dir := writeCatalogs(c, map[string]string{
+		"en.json":   `{"A": {"min": "A must be at least {min}"}}`,
+		"nl.yaml":   "A:\n  min: A moet minimaal {min} zijn\n",
+		"README.md": "not a catalog",
+	})
+
+	var stdout, stderr bytes.Buffer
+	c.Assert(run([]string{dir}, &stdout, &stderr), Equals, 0)
+	c.Assert(stdout.String(), Equals, "")
+}
+
+func (s *CatalogCheckSuite) TestInconsistent(c *C) {
+	dir := writeCatalogs(c, map[string]string{
+		"en.json": `{"A": {"min": "A must be at least {min}", "required": "A is required"}}`,
+		"nl.json": `{"A": {"min": "A moet minimaal {minimum} zijn"}}`,
+	})
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"-reference", "en", filepath.Join(dir, "en.json"), filepath.Join(dir, "nl.json")}, &stdout, &stderr)
+
+	c.Assert(code, Equals, 1)
+	c.Assert(stdout.String(), Equals, ""+
+		"nl: field \"A\" code \"min\": placeholder mismatch, missing {min}\n"+
+		"nl: field \"A\" code \"min\": placeholder mismatch, {minimum} not in en\n"+
+		"nl: field \"A\" code \"required\": missing translation\n")
+	c.Assert(stderr.String(), Equals, "catalogcheck: 3 inconsistencies in 2 catalogs\n")
+}
+
+func (s *CatalogCheckSuite) TestErrors(c *C) {
+	dir := writeCatalogs(c, map[string]string{
+		"en.json": `{"A": {"unknown": "A"}}`,
+	})
+
+	var stdout, stderr bytes.Buffer
+	c.Assert(run([]string{dir}, &stdout, &stderr), Equals, 2)
+	c.Assert(stderr.String(), Matches, "catalogcheck: .*en.json.*unknown error code.*\n")
+
+	stderr.Reset()
+	c.Assert(run([]string{"-reference", "de", writeCatalogs(c, map[string]string{"en.json": `{}`})}, &stdout, &stderr), Equals, 2)
+	c.Assert(stderr.String(), Equals, "catalogcheck: errortranslator: no catalog for the reference language de\n")
+
+	stderr.Reset()
+	c.Assert(run(nil, &stdout, &stderr), Equals, 2)
+	c.Assert(stderr.String(), Matches, "usage: catalogcheck (.|\n)*")
+}
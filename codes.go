@@ -0,0 +1,102 @@
+package errortranslator
+
+import (
+	"sync"
+	"sync/atomic"
+
+	validate "github.com/mbict/go-validate"
+)
+
+// DefaultCode is the error code of the default translation, the translation stored under the nil error.
+const DefaultCode = "default"
+
+var (
+	codesMu sync.Mutex
+	codes   atomic.Pointer[codeRegistry]
+)
+
+// codeRegistry holds the registered error codes in both directions, a registry is not modified after it is stored so
+// lookups do not need a lock. Registering a code stores a new registry.
+type codeRegistry struct {
+	errorsByCode map[string]error
+	codesByError map[error]string
+}
+
+func init() {
+	codes.Store(newCodeRegistry(map[string]error{
+		"required": validate.ErrRequired,
+		"min":      validate.ErrMin,
+		"max":      validate.ErrMax,
+	}))
+}
+
+// newCodeRegistry creates the registry for the codes, a error registered under multiple codes gets the first code
+// in alphabetical order.
+func newCodeRegistry(errorsByCode map[string]error) *codeRegistry {
+	r := &codeRegistry{
+		errorsByCode: errorsByCode,
+		codesByError: make(map[error]string, len(errorsByCode)),
+	}
+	for code, err := range errorsByCode {
+		if found, ok := r.codesByError[err]; !ok || code < found {
+			r.codesByError[err] = code
+		}
+	}
+	return r
+}
+
+// RegisterErrorCode registers a error under a code, the code is used to refer to the error in catalog files.
+// The errors of the validate package are registered by default as "required", "min" and "max". Error types can be
+// registered with their type key, e.g. RegisterErrorCode("number", TypeKey[*strconv.NumError]())
+// If the code is already registered it will be overwritten. Registering the DefaultCode, a empty code, a nil error or
+// a error that cannot be used as map key causes a panic.
+func RegisterErrorCode(code string, err error) {
+	if code == "" || code == DefaultCode || !isComparable(err) {
+		panic("errortranslator: invalid error code registration for code `" + code + "`")
+	}
+
+	codesMu.Lock()
+	defer codesMu.Unlock()
+
+	current := codes.Load().errorsByCode
+	errorsByCode := make(map[string]error, len(current)+1)
+	for c, e := range current {
+		errorsByCode[c] = e
+	}
+	errorsByCode[code] = err
+	codes.Store(newCodeRegistry(errorsByCode))
+}
+
+// ErrorForCode returns the error registered for the code. The DefaultCode returns a nil error.
+func ErrorForCode(code string) (error, bool) {
+	if code == DefaultCode {
+		return nil, true
+	}
+
+	err, ok := codes.Load().errorsByCode[code]
+	return err, ok
+}
+
+// CodeForError returns the code registered for the error, the nil error returns the DefaultCode.
+// When the error is registered under multiple codes the first code in alphabetical order is returned.
+func CodeForError(err error) (string, bool) {
+	if err == nil {
+		return DefaultCode, true
+	}
+
+	//only comparable errors can be registered, other errors would panic as map key
+	if !isComparable(err) {
+		return "", false
+	}
+	return codeForKey(err)
+}
+
+// codeForKey returns the code registered for a translation key, keys are always comparable.
+func codeForKey(key error) (string, bool) {
+	if key == nil {
+		return DefaultCode, true
+	}
+
+	code, ok := codes.Load().codesByError[key]
+	return code, ok
+}
@@ -0,0 +1,100 @@
+package errortranslator_test
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+
+	errortranslator "github.com/mbict/go-errortranslator"
+	validate "github.com/mbict/go-validate"
+	. "gopkg.in/check.v1"
+)
+
+type CodesSuite struct{}
+
+var _ = Suite(&CodesSuite{})
+
+var errCustom = errors.New("custom")
+
+func init() {
+	errortranslator.RegisterErrorCode("custom", errCustom)
+	errortranslator.RegisterErrorCode("number", errortranslator.TypeKey[*strconv.NumError]())
+}
+
+func (s *CodesSuite) TestErrorForCode(c *C) {
+	tests := []struct {
+		Code     string
+		Expected error
+		Ok       bool
+	}{
+		{Code: "required", Expected: validate.ErrRequired, Ok: true},
+		{Code: "min", Expected: validate.ErrMin, Ok: true},
+		{Code: "max", Expected: validate.ErrMax, Ok: true},
+		{Code: "default", Expected: nil, Ok: true},
+		{Code: "custom", Expected: errCustom, Ok: true},
+		{Code: "number", Expected: errortranslator.TypeKey[*strconv.NumError](), Ok: true},
+		{Code: "unknown", Expected: nil, Ok: false},
+	}
+
+	for _, test := range tests {
+		err, ok := errortranslator.ErrorForCode(test.Code)
+
+		c.Assert(ok, Equals, test.Ok, Commentf(test.Code))
+		c.Assert(err, Equals, test.Expected, Commentf(test.Code))
+	}
+}
+
+func (s *CodesSuite) TestRegisterInvalidErrorCode(c *C) {
+	c.Assert(func() { errortranslator.RegisterErrorCode("", errCustom) }, PanicMatches, `errortranslator: invalid error code .*`)
+	c.Assert(func() { errortranslator.RegisterErrorCode("default", errCustom) }, PanicMatches, `errortranslator: invalid error code .*`)
+	c.Assert(func() { errortranslator.RegisterErrorCode("nil", nil) }, PanicMatches, `errortranslator: invalid error code .*`)
+}
+
+type sliceError []string
+
+func (e sliceError) Error() string { return "slice error" }
+
+func (s *CodesSuite) TestCodeForError(c *C) {
+	errAlias := errors.New("alias")
+	errortranslator.RegisterErrorCode("alias_b", errAlias)
+	errortranslator.RegisterErrorCode("alias_a", errAlias)
+
+	tests := []struct {
+		Error    error
+		Expected string
+		Ok       bool
+	}{
+		{Error: validate.ErrRequired, Expected: "required", Ok: true},
+		{Error: nil, Expected: "default", Ok: true},
+		{Error: errAlias, Expected: "alias_a", Ok: true},
+		{Error: errors.New("unknown"), Expected: "", Ok: false},
+		{Error: sliceError{"a"}, Expected: "", Ok: false},
+		{Error: wrappedError{multiError{validate.ErrRequired}}, Expected: "", Ok: false},
+	}
+
+	for _, test := range tests {
+		code, ok := errortranslator.CodeForError(test.Error)
+
+		c.Assert(ok, Equals, test.Ok, Commentf("%v", test.Error))
+		c.Assert(code, Equals, test.Expected, Commentf("%v", test.Error))
+	}
+}
+
+func (s *CodesSuite) TestRegisterErrorCodeWhileTranslating(c *C) {
+	et := errortranslator.ErrorTranslator{validate.ErrRequired: "required"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			errortranslator.RegisterErrorCode("concurrent_"+strconv.Itoa(i), errors.New("concurrent"))
+		}(i)
+		go func() {
+			defer wg.Done()
+			translations, _ := et.TranslateDetails(validate.Errors{validate.ErrRequired})
+			c.Check(translations[0].Code, Equals, "required")
+		}()
+	}
+	wg.Wait()
+}
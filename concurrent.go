@@ -0,0 +1,138 @@
+package errortranslator
+
+import (
+	"sync"
+	"sync/atomic"
+
+	validate "github.com/mbict/go-validate"
+)
+
+// SyncTranslator is a translator that is safe for concurrent use. Translations can be added or replaced while other
+// goroutines translate.
+// Reads are lock free, they use a immutable snapshot of the translations. Every update copies the translations, adds
+// the change and swaps the snapshot, use Update to apply multiple changes with a single copy.
+type SyncTranslator struct {
+	mu       sync.Mutex
+	snapshot atomic.Pointer[Translator]
+}
+
+// NewSyncTranslator creates a new concurrency safe translator with a copy of the translations.
+func NewSyncTranslator(ft FieldErrorTranslator) *SyncTranslator {
+	st := &SyncTranslator{}
+	st.snapshot.Store(newSnapshot(ft.clone(), Options{}))
+	return st
+}
+
+// newSnapshot creates a snapshot of the translations, the pattern index is built once for all the readers
+func newSnapshot(ft FieldErrorTranslator, opts Options) *Translator {
+	patterns := newPatternIndex(ft)
+	return &Translator{Translations: ft, Options: opts, patterns: &patterns}
+}
+
+// Snapshot returns the current translations and options. The returned translator must not be modified, it is shared
+// with the goroutines that translate.
+func (st *SyncTranslator) Snapshot() *Translator {
+	return st.snapshot.Load()
+}
+
+// Update applies the changes of fn to a copy of the translations and makes the copy available to all readers at once.
+func (st *SyncTranslator) Update(fn func(ft FieldErrorTranslator)) *SyncTranslator {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	current := st.snapshot.Load()
+	ft := current.Translations.clone()
+	fn(ft)
+	st.snapshot.Store(newSnapshot(ft, current.Options))
+	return st
+}
+
+// Replace replaces all the translations, the translator uses a copy of the translations.
+func (st *SyncTranslator) Replace(ft FieldErrorTranslator) *SyncTranslator {
+	ft = ft.clone()
+
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.snapshot.Store(newSnapshot(ft, st.snapshot.Load().Options))
+	return st
+}
+
+// SetOptions sets the options used to translate, see Options.
+func (st *SyncTranslator) SetOptions(opts Options) *SyncTranslator {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	current := st.snapshot.Load()
+	st.snapshot.Store(&Translator{Translations: current.Translations, Options: opts, patterns: current.patterns})
+	return st
+}
+
+// AddTranslation works the same as FieldErrorTranslator.AddTranslation
+func (st *SyncTranslator) AddTranslation(field string, err error, message string) *SyncTranslator {
+	mustValidateMessage(message)
+	return st.Update(func(ft FieldErrorTranslator) {
+		ft.AddTranslation(field, err, message)
+	})
+}
+
+// SetDefaultTranslation works the same as FieldErrorTranslator.SetDefaultTranslation
+func (st *SyncTranslator) SetDefaultTranslation(field string, message string) *SyncTranslator {
+	return st.AddTranslation(field, nil, message)
+}
+
+// SetFallbackTranslation works the same as FieldErrorTranslator.SetFallbackTranslation
+func (st *SyncTranslator) SetFallbackTranslation(err error, message string) *SyncTranslator {
+	return st.AddTranslation("", err, message)
+}
+
+// SetFallbackDefaultTranslation works the same as FieldErrorTranslator.SetFallbackDefaultTranslation
+func (st *SyncTranslator) SetFallbackDefaultTranslation(message string) *SyncTranslator {
+	return st.AddTranslation("", nil, message)
+}
+
+// Translate works the same as FieldErrorTranslator.Translate with the current translations.
+func (st *SyncTranslator) Translate(errorMap validate.ErrorMap, fallback ...ErrorTranslator) (map[string]string, bool) {
+	return st.Snapshot().Translate(errorMap, fallback...)
+}
+
+// TranslateFirst works the same as FieldErrorTranslator.TranslateFirst with the current translations.
+func (st *SyncTranslator) TranslateFirst(errorMap validate.ErrorMap, fallback ...ErrorTranslator) (map[string]string, bool) {
+	return st.Snapshot().TranslateFirst(errorMap, fallback...)
+}
+
+// TranslateDetails works the same as FieldErrorTranslator.TranslateDetails with the current translations.
+func (st *SyncTranslator) TranslateDetails(errorMap validate.ErrorMap, fallback ...ErrorTranslator) (map[string][]Translation, bool) {
+	return st.Snapshot().TranslateDetails(errorMap, fallback...)
+}
+
+// TranslateFirstDetails works the same as FieldErrorTranslator.TranslateFirstDetails with the current translations.
+func (st *SyncTranslator) TranslateFirstDetails(errorMap validate.ErrorMap, fallback ...ErrorTranslator) (map[string][]Translation, bool) {
+	return st.Snapshot().TranslateFirstDetails(errorMap, fallback...)
+}
+
+// Joiner works the same as Translator.Joiner with the current options.
+func (st *SyncTranslator) Joiner() Joiner {
+	return st.Snapshot().Joiner()
+}
+
+// Match works the same as Translator.Match with the current translations.
+func (st *SyncTranslator) Match(field string) (string, bool) {
+	return st.Snapshot().Match(field)
+}
+
+// clone returns a copy of the translations, the ErrorTranslator of every field is copied too.
+func (ft FieldErrorTranslator) clone() FieldErrorTranslator {
+	c := make(FieldErrorTranslator, len(ft))
+	for field, et := range ft {
+		c[field] = et.clone()
+	}
+	return c
+}
+
+// clone returns a copy of the translations
+func (et ErrorTranslator) clone() ErrorTranslator {
+	c := make(ErrorTranslator, len(et))
+	for err, message := range et {
+		c[err] = message
+	}
+	return c
+}
@@ -0,0 +1,120 @@
+package errortranslator_test
+
+import (
+	"fmt"
+	"sync"
+
+	errortranslator "github.com/mbict/go-errortranslator"
+	validate "github.com/mbict/go-validate"
+	. "gopkg.in/check.v1"
+)
+
+type SyncTranslatorSuite struct{}
+
+var _ = Suite(&SyncTranslatorSuite{})
+
+func (s *SyncTranslatorSuite) TestTranslate(c *C) {
+	ft := errortranslator.New().AddTranslation("A", validate.ErrRequired, "A required translate")
+	st := errortranslator.NewSyncTranslator(ft)
+
+	//the translator uses a copy
+	ft.AddTranslation("A", validate.ErrMin, "A min translate")
+
+	result, ok := st.Translate(validate.ErrorMap{"A": validate.Errors{validate.ErrRequired, validate.ErrMin}})
+	c.Assert(ok, Equals, true)
+	c.Assert(result, DeepEquals, map[string]string{"A": "A required translate"})
+
+	st.AddTranslation("A", validate.ErrMin, "A min translate").
+		SetFallbackDefaultTranslation("nil default translate")
+
+	result, ok = st.TranslateFirst(validate.ErrorMap{
+		"A": validate.Errors{validate.ErrMin},
+		"B": validate.Errors{validate.ErrMax},
+	})
+	c.Assert(ok, Equals, true)
+	c.Assert(result, DeepEquals, map[string]string{"A": "A min translate", "B": "nil default translate"})
+}
+
+func (s *SyncTranslatorSuite) TestSnapshotIsImmutable(c *C) {
+	st := errortranslator.NewSyncTranslator(errortranslator.New())
+	snapshot := st.Snapshot()
+
+	st.SetFallbackTranslation(validate.ErrRequired, "required translate")
+
+	c.Assert(snapshot.Translations, DeepEquals, errortranslator.New())
+	c.Assert(st.Snapshot().Translations, DeepEquals, errortranslator.FieldErrorTranslator{
+		"": errortranslator.ErrorTranslator{validate.ErrRequired: "required translate"},
+	})
+}
+
+func (s *SyncTranslatorSuite) TestUpdateReplaceAndOptions(c *C) {
+	st := errortranslator.NewSyncTranslator(errortranslator.New())
+	st.SetOptions(errortranslator.Options{Hierarchical: true})
+
+	st.Update(func(ft errortranslator.FieldErrorTranslator) {
+		ft.AddTranslation("address", validate.ErrRequired, "address required translate")
+		ft.AddTranslation("address", validate.ErrMin, "address min translate")
+	})
+
+	key, ok := st.Match("address.street")
+	c.Assert(key, Equals, "address")
+	c.Assert(ok, Equals, true)
+
+	st.Replace(errortranslator.New().SetDefaultTranslation("name", "name default translate"))
+
+	details, ok := st.TranslateDetails(validate.ErrorMap{
+		"address": validate.Errors{validate.ErrRequired},
+		"name":    validate.Errors{validate.ErrRequired},
+	})
+	c.Assert(ok, Equals, false)
+	c.Assert(details["name"][0].Message, Equals, "name default translate")
+	c.Assert(st.Snapshot().Options.Hierarchical, Equals, true)
+}
+
+func (s *SyncTranslatorSuite) TestConcurrentUpdates(c *C) {
+	st := errortranslator.NewSyncTranslator(errortranslator.New().
+		SetFallbackTranslation(validate.ErrRequired, "required translate"))
+
+	errorMap := validate.ErrorMap{"field": validate.Errors{validate.ErrRequired}}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				field := fmt.Sprintf("field%d_%d", i, j)
+				switch j % 3 {
+				case 0:
+					st.AddTranslation(field, validate.ErrMin, "min translate")
+				case 1:
+					st.Update(func(ft errortranslator.FieldErrorTranslator) {
+						ft.SetDefaultTranslation(field, "default translate")
+					})
+				default:
+					st.SetOptions(errortranslator.Options{Dedupe: j%2 == 0})
+				}
+			}
+		}(i)
+	}
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 500; j++ {
+				result, ok := st.Translate(errorMap)
+				if !ok || result["field"] != "required translate" {
+					c.Errorf("unexpected translation %v", result)
+					return
+				}
+				st.TranslateFirstDetails(validate.ErrorMap{"field0_0": validate.Errors{validate.ErrMin}})
+				st.Match("field1_1")
+			}
+		}()
+	}
+	wg.Wait()
+
+	//4 goroutines each added 67 fields with AddTranslation or Update, plus the fallback field
+	c.Assert(st.Snapshot().Translations, HasLen, 4*67+1)
+}
@@ -0,0 +1,310 @@
+package errortranslator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// InconsistencyKind is the kind of difference between two catalogs found by CheckCatalogs
+type InconsistencyKind string
+
+const (
+	// MissingTranslation is a translation of the reference catalog that is not in the catalog.
+	MissingTranslation InconsistencyKind = "missing translation"
+
+	// ExtraTranslation is a translation of the catalog that is not in the reference catalog.
+	ExtraTranslation InconsistencyKind = "extra translation"
+
+	// PlaceholderMismatch is a translation that does not use the same placeholders as the reference translation.
+	PlaceholderMismatch InconsistencyKind = "placeholder mismatch"
+
+	// PluralMismatch is a plural placeholder that is not plural in both translations, or that does not have a variant
+	// for every plural category of the language or has a variant for a category the language does not use.
+	PluralMismatch InconsistencyKind = "plural mismatch"
+
+	// InvalidMessage is a translation that is not well formed, see ValidateMessage.
+	InvalidMessage InconsistencyKind = "invalid message"
+)
+
+// Inconsistency describes a difference between the translation of a field and error in a catalog and the reference
+// catalog.
+type Inconsistency struct {
+	Locale language.Tag
+	Field  string
+	Err    error
+	Kind   InconsistencyKind
+	Detail string
+}
+
+func (i Inconsistency) String() string {
+	s := fmt.Sprintf("%s: field %q code %q: %s", i.Locale, i.Field, errorCode(i.Err), i.Kind)
+	if i.Detail != "" {
+		s += ", " + i.Detail
+	}
+	return s
+}
+
+// CheckCatalogs compares the catalogs of all languages with the catalog of the reference language and reports
+// translations that are missing or extra, translations that use other placeholders than the reference translation
+// and plural placeholders that do not match the plural categories of their language.
+// The result is sorted by language, field, error code and kind. The reference language must be one of the catalogs.
+//
+//	inconsistencies, err := errortranslator.CheckCatalogs(language.English, map[language.Tag]errortranslator.FieldErrorTranslator{
+//		language.English: en,
+//		language.Dutch:   nl,
+//	})
+func CheckCatalogs(reference language.Tag, catalogs map[language.Tag]FieldErrorTranslator) ([]Inconsistency, error) {
+	ref, ok := catalogs[reference]
+	if !ok {
+		return nil, fmt.Errorf("errortranslator: no catalog for the reference language %s", reference)
+	}
+
+	var result []Inconsistency
+	result = append(result, checkMessages(reference, ref)...)
+	for locale, ft := range catalogs {
+		if locale != reference {
+			result = append(result, checkCatalog(reference, ref, locale, ft)...)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Locale != b.Locale {
+			return a.Locale.String() < b.Locale.String()
+		}
+		if a.Field != b.Field {
+			return a.Field < b.Field
+		}
+		if codeA, codeB := errorCode(a.Err), errorCode(b.Err); codeA != codeB {
+			return codeA < codeB
+		}
+		return a.Kind < b.Kind
+	})
+	return result, nil
+}
+
+// CheckLocales compares the catalogs of all languages of the LocaleTranslator with the catalog of the default
+// language, see CheckCatalogs.
+func CheckLocales(lt *LocaleTranslator) ([]Inconsistency, error) {
+	catalogs := make(map[language.Tag]FieldErrorTranslator)
+	for _, locale := range lt.Locales() {
+		catalogs[locale] = lt.translators[locale]
+	}
+	return CheckCatalogs(lt.DefaultLocale(), catalogs)
+}
+
+// checkMessages reports the malformed messages and plural mismatches of the reference catalog itself
+func checkMessages(locale language.Tag, ft FieldErrorTranslator) []Inconsistency {
+	var result []Inconsistency
+	categories := languageCategories(locale)
+	for field, et := range ft {
+		for err, message := range et {
+			found := Inconsistency{Locale: locale, Field: field, Err: err}
+			m, perr := parseMessage(message)
+			if perr != nil {
+				found.Kind, found.Detail = InvalidMessage, perr.Error()
+				result = append(result, found)
+				continue
+			}
+
+			for _, detail := range m.placeholders().categoryMismatches(categories) {
+				found.Kind, found.Detail = PluralMismatch, detail
+				result = append(result, found)
+			}
+		}
+	}
+	return result
+}
+
+// checkCatalog compares the catalog of a language with the reference catalog
+func checkCatalog(reference language.Tag, ref FieldErrorTranslator, locale language.Tag, ft FieldErrorTranslator) []Inconsistency {
+	var result []Inconsistency
+	for field, et := range ref {
+		for err := range et {
+			if _, ok := ft[field][err]; !ok {
+				result = append(result, Inconsistency{Locale: locale, Field: field, Err: err, Kind: MissingTranslation})
+			}
+		}
+	}
+
+	categories := languageCategories(locale)
+	for field, et := range ft {
+		for err, message := range et {
+			found := Inconsistency{Locale: locale, Field: field, Err: err}
+			refMessage, ok := ref[field][err]
+			if !ok {
+				found.Kind = ExtraTranslation
+				result = append(result, found)
+			}
+
+			m, perr := parseMessage(message)
+			if perr != nil {
+				found.Kind, found.Detail = InvalidMessage, perr.Error()
+				result = append(result, found)
+				continue
+			}
+			placeholders := m.placeholders()
+
+			for _, detail := range placeholders.categoryMismatches(categories) {
+				found.Kind, found.Detail = PluralMismatch, detail
+				result = append(result, found)
+			}
+
+			refParsed, perr := parseMessage(refMessage)
+			if !ok || perr != nil {
+				continue
+			}
+
+			for _, mismatch := range placeholders.compare(refParsed.placeholders(), reference) {
+				found.Kind, found.Detail = mismatch.kind, mismatch.detail
+				result = append(result, found)
+			}
+		}
+	}
+	return result
+}
+
+// placeholderSet holds the names of the placeholders of a message, with the selectors of the plural placeholders.
+// A placeholder that is used both plain and plural is plural.
+type placeholderSet map[string][]string
+
+// placeholders returns the placeholders of the message and its plural variants
+func (m parsedMessage) placeholders() placeholderSet {
+	set := make(placeholderSet)
+	m.collectPlaceholders(set)
+	return set
+}
+
+func (m parsedMessage) collectPlaceholders(set placeholderSet) {
+	for _, s := range m {
+		if s.param == "" {
+			continue
+		}
+
+		if s.plural == nil {
+			if _, ok := set[s.param]; !ok {
+				set[s.param] = nil
+			}
+			continue
+		}
+
+		selectors := set[s.param]
+		for selector, variant := range s.plural {
+			selectors = append(selectors, selector)
+			variant.collectPlaceholders(set)
+		}
+		set[s.param] = selectors
+	}
+}
+
+func (set placeholderSet) names() []string {
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// categoryMismatches returns a description of every plural placeholder that misses a variant for a plural category
+// of the language or that has a variant for a category the language does not use. Exact selectors (=N) are ignored.
+func (set placeholderSet) categoryMismatches(categories map[string]bool) []string {
+	var result []string
+	for _, name := range set.names() {
+		selectors := set[name]
+		if selectors == nil {
+			continue
+		}
+
+		used := make(map[string]bool)
+		for _, selector := range selectors {
+			used[selector] = true
+		}
+
+		var missing, unused []string
+		for _, category := range pluralCategories {
+			switch {
+			case categories[category] && !used[category]:
+				missing = append(missing, category)
+			case !categories[category] && used[category]:
+				unused = append(unused, category)
+			}
+		}
+
+		if len(missing) > 0 {
+			result = append(result, fmt.Sprintf("{%s} has no variant for %s", name, strings.Join(missing, ", ")))
+		}
+		if len(unused) > 0 {
+			result = append(result, fmt.Sprintf("{%s} has a variant for %s that the language does not use", name, strings.Join(unused, ", ")))
+		}
+	}
+	return result
+}
+
+type placeholderMismatch struct {
+	kind   InconsistencyKind
+	detail string
+}
+
+// compare returns the differences with the placeholders of the reference message
+func (set placeholderSet) compare(ref placeholderSet, reference language.Tag) []placeholderMismatch {
+	var missing, unknown []string
+	for _, name := range ref.names() {
+		if _, ok := set[name]; !ok {
+			missing = append(missing, "{"+name+"}")
+		}
+	}
+	for _, name := range set.names() {
+		if _, ok := ref[name]; !ok {
+			unknown = append(unknown, "{"+name+"}")
+		}
+	}
+
+	var result []placeholderMismatch
+	if len(missing) > 0 {
+		result = append(result, placeholderMismatch{PlaceholderMismatch, fmt.Sprintf("missing %s", strings.Join(missing, ", "))})
+	}
+	if len(unknown) > 0 {
+		result = append(result, placeholderMismatch{PlaceholderMismatch, fmt.Sprintf("%s not in %s", strings.Join(unknown, ", "), reference)})
+	}
+
+	for _, name := range ref.names() {
+		selectors, ok := set[name]
+		if !ok || (selectors == nil) == (ref[name] == nil) {
+			continue
+		}
+
+		if selectors == nil {
+			result = append(result, placeholderMismatch{PluralMismatch, fmt.Sprintf("{%s} is plural in %s", name, reference)})
+		} else {
+			result = append(result, placeholderMismatch{PluralMismatch, fmt.Sprintf("{%s} is not plural in %s", name, reference)})
+		}
+	}
+	return result
+}
+
+// languageCategories returns the plural categories the language uses for integers and decimals with up to two
+// fraction digits. The English categories are used when the language is undefined.
+func languageCategories(locale language.Tag) map[string]bool {
+	categories := map[string]bool{"other": true}
+	for i := 0; i <= 1000; i++ {
+		categories[pluralCategory(locale, fmt.Sprint(i))] = true
+	}
+	for i := 0; i <= 100; i++ {
+		for _, fraction := range []string{"0", "1", "5", "00", "25"} {
+			categories[pluralCategory(locale, fmt.Sprintf("%d.%s", i, fraction))] = true
+		}
+	}
+	return categories
+}
+
+// errorCode returns the registered code of the error, or the error message when it is not registered
+func errorCode(err error) string {
+	if code, ok := CodeForError(err); ok {
+		return code
+	}
+	return err.Error()
+}
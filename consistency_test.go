@@ -0,0 +1,85 @@
+package errortranslator_test
+
+import (
+	errortranslator "github.com/mbict/go-errortranslator"
+	validate "github.com/mbict/go-validate"
+	"golang.org/x/text/language"
+	. "gopkg.in/check.v1"
+)
+
+type ConsistencySuite struct{}
+
+var _ = Suite(&ConsistencySuite{})
+
+func (s *ConsistencySuite) TestCheckCatalogs(c *C) {
+	en := errortranslator.New().
+		AddTranslation("Email", validate.ErrRequired, "Please enter your email").
+		AddTranslation("Name", validate.ErrMin, "{label} must be at least {min} characters").
+		AddTranslation("Items", validate.ErrMax, "{max, plural, one {# item} other {# items}} allowed")
+	nl := errortranslator.New().
+		AddTranslation("Name", validate.ErrMin, "{label} moet minimaal {minimum} tekens zijn").
+		AddTranslation("Items", validate.ErrMax, "{max} items toegestaan").
+		AddTranslation("Phone", validate.ErrRequired, "Vul je telefoonnummer in")
+	pl := errortranslator.New().
+		AddTranslation("Email", validate.ErrRequired, "Podaj adres e-mail").
+		AddTranslation("Name", validate.ErrMin, "{label} musi mieć co najmniej {min} znaków").
+		AddTranslation("Items", validate.ErrMax, "{max, plural, one {# element} two {# elementy} other {# elementów}}")
+
+	inconsistencies, err := errortranslator.CheckCatalogs(language.English, map[language.Tag]errortranslator.FieldErrorTranslator{
+		language.English: en,
+		language.Dutch:   nl,
+		language.Polish:  pl,
+	})
+
+	c.Assert(err, IsNil)
+	c.Assert(inconsistencies, DeepEquals, []errortranslator.Inconsistency{
+		{Locale: language.Dutch, Field: "Email", Err: validate.ErrRequired, Kind: errortranslator.MissingTranslation},
+		{Locale: language.Dutch, Field: "Items", Err: validate.ErrMax, Kind: errortranslator.PluralMismatch, Detail: "{max} is plural in en"},
+		{Locale: language.Dutch, Field: "Name", Err: validate.ErrMin, Kind: errortranslator.PlaceholderMismatch, Detail: "missing {min}"},
+		{Locale: language.Dutch, Field: "Name", Err: validate.ErrMin, Kind: errortranslator.PlaceholderMismatch, Detail: "{minimum} not in en"},
+		{Locale: language.Dutch, Field: "Phone", Err: validate.ErrRequired, Kind: errortranslator.ExtraTranslation},
+		{Locale: language.Polish, Field: "Items", Err: validate.ErrMax, Kind: errortranslator.PluralMismatch, Detail: "{max} has no variant for few, many"},
+		{Locale: language.Polish, Field: "Items", Err: validate.ErrMax, Kind: errortranslator.PluralMismatch, Detail: "{max} has a variant for two that the language does not use"},
+	})
+
+	c.Assert(inconsistencies[0].String(), Equals, `nl: field "Email" code "required": missing translation`)
+	c.Assert(inconsistencies[1].String(), Equals, `nl: field "Items" code "max": plural mismatch, {max} is plural in en`)
+}
+
+func (s *ConsistencySuite) TestCheckCatalogsReference(c *C) {
+	en := errortranslator.FieldErrorTranslator{
+		"A": errortranslator.ErrorTranslator{
+			validate.ErrMin: "{min, plural, one {# char} few {# chars} other {# chars}}",
+			nil:             "{broken",
+		},
+	}
+
+	inconsistencies, err := errortranslator.CheckCatalogs(language.English, map[language.Tag]errortranslator.FieldErrorTranslator{
+		language.English: en,
+	})
+
+	c.Assert(err, IsNil)
+	c.Assert(inconsistencies, HasLen, 2)
+	c.Assert(inconsistencies[0].Kind, Equals, errortranslator.InvalidMessage)
+	c.Assert(inconsistencies[0].Err, IsNil)
+	c.Assert(inconsistencies[1].Detail, Equals, "{min} has a variant for few that the language does not use")
+
+	_, err = errortranslator.CheckCatalogs(language.German, map[language.Tag]errortranslator.FieldErrorTranslator{
+		language.English: en,
+	})
+	c.Assert(err, ErrorMatches, "errortranslator: no catalog for the reference language de")
+}
+
+func (s *ConsistencySuite) TestCheckLocales(c *C) {
+	lt := errortranslator.NewLocaleTranslator(language.English)
+	lt.Locale(language.English).AddTranslation("A", validate.ErrRequired, "A is required")
+	lt.Locale(language.Dutch).AddTranslation("A", validate.ErrRequired, "A is verplicht")
+	lt.Locale(language.German)
+
+	inconsistencies, err := errortranslator.CheckLocales(lt)
+
+	c.Assert(err, IsNil)
+	c.Assert(inconsistencies, DeepEquals, []errortranslator.Inconsistency{
+		{Locale: language.German, Field: "A", Err: validate.ErrRequired, Kind: errortranslator.MissingTranslation},
+	})
+}
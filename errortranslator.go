@@ -1,7 +1,10 @@
 package errortranslator
 
 import (
+	"reflect"
+
 	validate "github.com/mbict/go-validate"
+	"golang.org/x/text/language"
 )
 
 // ErrorTranslator is a map that stores a human readable translations for errors.
@@ -10,8 +13,10 @@ type ErrorTranslator map[error]string
 // AddTranslation adds a new translation to the map. It is stored in the map by the error object as key
 // If a translation is already present is will be overwritten by the new translation
 // The function returns a reference to the ErrorTranslator and is therefor very useful for chaining AddTranslation functions
+// The message may contain placeholders (see ValidateMessage), a malformed message causes a panic.
 // Equivalent to this function is: errortranslator[err] = message
 func (et ErrorTranslator) AddTranslation(err error, message string) ErrorTranslator {
+	mustValidateMessage(message)
 	et[err] = message
 	return et
 }
@@ -24,53 +29,178 @@ func (et ErrorTranslator) SetDefaultTranslation(message string) ErrorTranslator
 
 // TranslateError tries to translate the error into a human readable message.
 // It will try to lookup the error in its map and returns the value as the message/translation,
+// When the error itself is not in the map the wrapped errors (Unwrap() error and Unwrap() []error) are tried,
+// the outermost registered error in the chain wins. Translations for error types (see TypeKey) are tried after that.
 // A fallback is used (if provided) when no match is found in the current map.
 // When no match is found in the map or the fallback the default translation is returned (if set)
 // When no match can be made at all the function will return a empty string and false as the succes flag
+// Placeholders in the translation are replaced with the parameters provided by the error (see ErrorParams).
 func (et ErrorTranslator) TranslateError(err error, fallback ...ErrorTranslator) (string, bool) {
-	translation, ok := et[err]
-	if !ok {
-		translation, ok = et[nil]
+	return et.translateError(messageContext{}, err, fallback)
+}
+
+// Validate checks all the translation messages in the map and returns the first malformed message error found.
+// Useful for maps that are not filled with AddTranslation.
+func (et ErrorTranslator) Validate() error {
+	for _, message := range et {
+		if err := ValidateMessage(message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (et ErrorTranslator) translateError(ctx messageContext, err error, fallback []ErrorTranslator) (string, bool) {
+	translation, _, ok := et.translate(ctx, err, fallback)
+	return translation.Message, ok
+}
+
+// translate translates the error with the first translator in the chain (et followed by the fallback) that has a
+// matching or default translation. The index of the translator that provided the translation is returned.
+func (et ErrorTranslator) translate(ctx messageContext, err error, fallback []ErrorTranslator) (Translation, int, bool) {
+	for i := 0; i <= len(fallback); i++ {
+		translator := et
+		if i > 0 {
+			translator = fallback[i-1]
+		}
+
+		key, message, ok := translator.lookup(err)
 		if !ok {
 			//fallback to default
-			if len(fallback) >= 1 {
-				return fallback[0].TranslateError(err, fallback[1:]...)
+			message, ok = translator[nil]
+		}
+
+		if ok {
+			return newTranslation(key, renderMessage(message, ctx, err), err), i, true
+		}
+	}
+	return Translation{}, -1, false
+}
+
+// lookup searches the map for the error or any of the errors it wraps.
+// Value translations have precedence over type translations (see TypeKey), the chain is walked depth first in the
+// same order as errors.Is does. The key of the translation in the map is returned with the translation.
+func (et ErrorTranslator) lookup(err error) (error, string, bool) {
+	var (
+		key         error
+		translation string
+	)
+	found := walkErrors(err, func(err error) bool {
+		if !isComparable(err) {
+			return false
+		}
+
+		var ok bool
+		key = err
+		translation, ok = et[err]
+		return ok
+	})
+	if found {
+		return key, translation, true
+	}
+
+	matchers := et.typeMatchers()
+	if len(matchers) == 0 {
+		return nil, "", false
+	}
+
+	found = walkErrors(err, func(err error) bool {
+		for _, m := range matchers {
+			if m.matchError(err) {
+				key, translation = m, et[m]
+				return true
 			}
-			return "", false
 		}
+		return false
+	})
+	if !found {
+		return nil, "", false
 	}
-	return translation, true
+	return key, translation, true
+}
+
+// isComparable reports if the error can be used as map key and compared with ==. A comparable type is not enough, a
+// struct with a error field that holds a slice panics as well, so the dynamic value is checked.
+func isComparable(err error) bool {
+	return err != nil && reflect.ValueOf(err).Comparable()
+}
+
+// walkErrors calls fn for the error and all the errors it wraps until fn returns true.
+// Both Unwrap() error and Unwrap() []error are followed.
+func walkErrors(err error, fn func(error) bool) bool {
+	if err == nil {
+		return false
+	}
+
+	if fn(err) {
+		return true
+	}
+
+	switch x := err.(type) {
+	case interface{ Unwrap() error }:
+		return walkErrors(x.Unwrap(), fn)
+	case interface{ Unwrap() []error }:
+		for _, err := range x.Unwrap() {
+			if walkErrors(err, fn) {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // Translate will translate a slice of errors into a single human readable string.
 // The validate.Errors is used from the validation package and is a slice with errors
 func (et ErrorTranslator) Translate(errs validate.Errors, fallback ...ErrorTranslator) (string, bool) {
-	return et.translateErrors(errs, false, fallback)
+	return et.TranslateJoin(errs, DefaultJoiner, fallback...)
+}
+
+// TranslateJoin works the same as Translate but joins the messages with the joiner, e.g. SeparatorJoiner("; ").
+func (et ErrorTranslator) TranslateJoin(errs validate.Errors, joiner Joiner, fallback ...ErrorTranslator) (string, bool) {
+	translations, _ := et.TranslateDetails(errs, fallback...)
+	return joinTranslations(joiner, language.Und, translations)
 }
 
 // TranslateFirst will only translate the first translatable error found in the map.
 func (et ErrorTranslator) TranslateFirst(errs validate.Errors, fallback ...ErrorTranslator) (string, bool) {
+	translations, _ := et.TranslateFirstDetails(errs, fallback...)
+	return joinTranslations(DefaultJoiner, language.Und, translations)
+}
+
+// TranslateDetails translates a slice of errors into a Translation per translated error, in the order of the errors.
+// UsedFallback is set for the translations provided by the fallback. Errors without translation are left out and
+// false is returned when none of the errors could be translated.
+func (et ErrorTranslator) TranslateDetails(errs validate.Errors, fallback ...ErrorTranslator) ([]Translation, bool) {
+	return et.translateErrors(errs, false, fallback)
+}
+
+// TranslateFirstDetails works the same as TranslateDetails but will only translate the first translatable error.
+func (et ErrorTranslator) TranslateFirstDetails(errs validate.Errors, fallback ...ErrorTranslator) ([]Translation, bool) {
 	return et.translateErrors(errs, true, fallback)
 }
 
-func (et ErrorTranslator) translateErrors(errs validate.Errors, firstOnly bool, fallback []ErrorTranslator) (string, bool) {
-	result := ""
+func (et ErrorTranslator) translateErrors(errs validate.Errors, firstOnly bool, fallback []ErrorTranslator) ([]Translation, bool) {
+	return translateErrors(errs, firstOnly, func(err error) (Translation, bool) {
+		translation, i, ok := et.translate(messageContext{}, err, fallback)
+		translation.UsedFallback = i > 0
+		return translation, ok
+	})
+}
+
+// translateErrors translates every error with the translate function and collects the translations.
+func translateErrors(errs validate.Errors, firstOnly bool, translate func(error) (Translation, bool)) ([]Translation, bool) {
+	var result []Translation
 	for _, err := range errs {
-		translation, ok := et.TranslateError(err, fallback...)
+		translation, ok := translate(err)
 		if !ok {
 			continue
 		}
 
-		if result == "" {
-			result = translation
-		} else {
-			result = result + ", " + translation
-		}
-
+		result = append(result, translation)
 		if firstOnly {
 			//first message is enough head over to the next field
-			return result, true
+			break
 		}
 	}
-	return result, !(result == "")
+	return result, len(result) > 0
 }
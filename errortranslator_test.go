@@ -1,10 +1,13 @@
 package errortranslator_test
 
 import (
+	"errors"
+	"fmt"
+	"testing"
+
 	errortranslator "github.com/mbict/go-errortranslator"
 	validate "github.com/mbict/go-validate"
 	. "gopkg.in/check.v1"
-	"testing"
 )
 
 func Test(t *testing.T) {
@@ -78,6 +81,88 @@ func (s *ErrorTranslatorSuite) TestTranslateErrorWitDefault(c *C) {
 	c.Assert(ok, Equals, true)
 }
 
+type wrappedError struct {
+	err error
+}
+
+func (e wrappedError) Error() string { return "wrapped: " + e.err.Error() }
+func (e wrappedError) Unwrap() error { return e.err }
+
+type multiError []error
+
+func (e multiError) Error() string   { return "multiple errors" }
+func (e multiError) Unwrap() []error { return e }
+
+func (s *ErrorTranslatorSuite) TestTranslateWrappedError(c *C) {
+	errContext := errors.New("context")
+	et := errortranslator.ErrorTranslator{
+		validate.ErrRequired: "required translate",
+		validate.ErrMin:      "min translate",
+		errContext:           "context translate",
+		nil:                  "nil default translate",
+	}
+
+	tests := []struct {
+		Description string
+		Error       error
+		Expected    string
+	}{
+		{
+			Description: "wrapped with fmt.Errorf",
+			Error:       fmt.Errorf("field: %w", validate.ErrRequired),
+			Expected:    "required translate",
+		}, {
+			Description: "wrapped multiple times",
+			Error:       fmt.Errorf("outer: %w", wrappedError{fmt.Errorf("inner: %w", validate.ErrMin)}),
+			Expected:    "min translate",
+		}, {
+			Description: "outermost registered error wins",
+			Error:       fmt.Errorf("%w: %w", wrappedError{errContext}, validate.ErrRequired),
+			Expected:    "context translate",
+		}, {
+			Description: "joined errors are searched in order",
+			Error:       errors.Join(validate.ErrMax, validate.ErrMin, validate.ErrRequired),
+			Expected:    "min translate",
+		}, {
+			Description: "non comparable errors are unwrapped",
+			Error:       fmt.Errorf("field: %w", multiError{validate.ErrMax, validate.ErrRequired}),
+			Expected:    "required translate",
+		}, {
+			Description: "comparable wrapper holding a non comparable error",
+			Error:       wrappedError{multiError{validate.ErrRequired}},
+			Expected:    "required translate",
+		}, {
+			Description: "no match in the chain falls back to nil translation",
+			Error:       fmt.Errorf("field: %w", validate.ErrMax),
+			Expected:    "nil default translate",
+		},
+	}
+
+	for _, test := range tests {
+		translated, ok := et.TranslateError(test.Error)
+
+		c.Assert(ok, Equals, true, Commentf(test.Description))
+		c.Assert(translated, Equals, test.Expected, Commentf(test.Description))
+	}
+}
+
+func (s *ErrorTranslatorSuite) TestTranslateWrappedErrorFallback(c *C) {
+	et := errortranslator.ErrorTranslator{
+		validate.ErrRequired: "required translate",
+	}
+	fallback := errortranslator.ErrorTranslator{
+		validate.ErrMin: "fallback min translate",
+	}
+
+	translated, ok := et.TranslateError(fmt.Errorf("field: %w", validate.ErrMin), fallback)
+	c.Assert(translated, Equals, "fallback min translate")
+	c.Assert(ok, Equals, true)
+
+	translated, ok = et.TranslateError(fmt.Errorf("field: %w", validate.ErrMax), fallback)
+	c.Assert(translated, Equals, "")
+	c.Assert(ok, Equals, false)
+}
+
 func (s *ErrorTranslatorSuite) TestTranslate(c *C) {
 	et := errortranslator.ErrorTranslator{
 		validate.ErrRequired: "required translate",
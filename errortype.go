@@ -0,0 +1,67 @@
+package errortranslator
+
+import (
+	"reflect"
+	"sort"
+)
+
+// typeMatcher is implemented by the map keys that match errors by their type instead of their value.
+type typeMatcher interface {
+	error
+	matchError(err error) bool
+}
+
+// typeKey is the map key used for translations that match all errors of type T.
+// It is a empty struct so every typeKey of the same type is equal and usable as map key.
+type typeKey[T error] struct{}
+
+// Error returns the name of the matched type, e.g. `type *strconv.NumError`
+func (typeKey[T]) Error() string {
+	return "type " + reflect.TypeOf((*T)(nil)).Elem().String()
+}
+
+// matchError reports if the error itself (not the errors it wraps) is of type T, the same rules as errors.As are used.
+func (typeKey[T]) matchError(err error) bool {
+	if _, ok := err.(T); ok {
+		return true
+	}
+
+	if x, ok := err.(interface{ As(interface{}) bool }); ok {
+		var target T
+		return x.As(&target)
+	}
+	return false
+}
+
+// TypeKey returns the key that matches all errors of type T. It can be used as error in all functions and maps that
+// take a error key, for example: fielderrortranslator.AddTranslation("A", TypeKey[*strconv.NumError](), message)
+//
+// A translation stored under a type key is matched like errors.As does, it is only used when no value translation
+// matches the error or any of the errors it wraps. When multiple type translations match, the type that matches the
+// outermost error in the chain wins. The nil default translation is only used when no type translation matched.
+func TypeKey[T error]() error {
+	return typeKey[T]{}
+}
+
+// AddTypeTranslation adds a new translation for all errors of type T to the map.
+// If a translation is already present is will be overwritten by the new translation
+// Equivalent to this function is: errortranslator[TypeKey[T]()] = message
+func AddTypeTranslation[T error](et ErrorTranslator, message string) ErrorTranslator {
+	return et.AddTranslation(TypeKey[T](), message)
+}
+
+// typeMatchers returns all type keys in the map, sorted by name to get a deterministic match order for errors that
+// match multiple types.
+func (et ErrorTranslator) typeMatchers() []typeMatcher {
+	var matchers []typeMatcher
+	for err := range et {
+		if m, ok := err.(typeMatcher); ok {
+			matchers = append(matchers, m)
+		}
+	}
+
+	sort.Slice(matchers, func(i, j int) bool {
+		return matchers[i].Error() < matchers[j].Error()
+	})
+	return matchers
+}
@@ -0,0 +1,113 @@
+package errortranslator_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	errortranslator "github.com/mbict/go-errortranslator"
+	validate "github.com/mbict/go-validate"
+	. "gopkg.in/check.v1"
+)
+
+type ErrorTypeSuite struct{}
+
+var _ = Suite(&ErrorTypeSuite{})
+
+type minError struct {
+	Min int
+}
+
+func (e *minError) Error() string { return fmt.Sprintf("less than %d", e.Min) }
+
+func (s *ErrorTypeSuite) TestAddTypeTranslation(c *C) {
+	et := errortranslator.ErrorTranslator{}
+
+	errortranslator.AddTypeTranslation[*minError](et, "min type")
+
+	c.Assert(et, DeepEquals, errortranslator.ErrorTranslator{
+		errortranslator.TypeKey[*minError](): "min type",
+	})
+
+	//overwrite
+	errortranslator.AddTypeTranslation[*minError](et, "overwritten")
+
+	c.Assert(et, DeepEquals, errortranslator.ErrorTranslator{
+		errortranslator.TypeKey[*minError](): "overwritten",
+	})
+}
+
+func (s *ErrorTypeSuite) TestTypeKeyError(c *C) {
+	c.Assert(errortranslator.TypeKey[*strconv.NumError]().Error(), Equals, "type *strconv.NumError")
+}
+
+func (s *ErrorTypeSuite) TestTranslateErrorByType(c *C) {
+	_, errNum := strconv.Atoi("abc")
+	errJSON := json.Unmarshal([]byte(`{"A": "abc"}`), &struct{ A int }{})
+
+	et := errortranslator.ErrorTranslator{
+		validate.ErrMin: "min translate",
+		nil:             "nil default translate",
+	}
+	errortranslator.AddTypeTranslation[*minError](et, "min type translate")
+	errortranslator.AddTypeTranslation[*strconv.NumError](et, "number type translate")
+	errortranslator.AddTypeTranslation[*json.UnmarshalTypeError](et, "json type translate")
+
+	tests := []struct {
+		Description string
+		Error       error
+		Expected    string
+	}{
+		{
+			Description: "type match",
+			Error:       &minError{Min: 5},
+			Expected:    "min type translate",
+		}, {
+			Description: "wrapped type match",
+			Error:       fmt.Errorf("field: %w", errNum),
+			Expected:    "number type translate",
+		}, {
+			Description: "decoding error",
+			Error:       errJSON,
+			Expected:    "json type translate",
+		}, {
+			Description: "value match has precedence over type match",
+			Error:       fmt.Errorf("%w: %w", &minError{Min: 5}, validate.ErrMin),
+			Expected:    "min translate",
+		}, {
+			Description: "outermost type match wins",
+			Error:       fmt.Errorf("%w: %w", errNum, &minError{Min: 5}),
+			Expected:    "number type translate",
+		}, {
+			Description: "no type matches falls back to nil translation",
+			Error:       validate.ErrMax,
+			Expected:    "nil default translate",
+		},
+	}
+
+	for _, test := range tests {
+		translated, ok := et.TranslateError(test.Error)
+
+		c.Assert(ok, Equals, true, Commentf(test.Description))
+		c.Assert(translated, Equals, test.Expected, Commentf(test.Description))
+	}
+}
+
+func (s *ErrorTypeSuite) TestFieldTranslateByType(c *C) {
+	ft := errortranslator.New().
+		AddTranslation("A", validate.ErrRequired, "a required translate").
+		AddTranslation("A", errortranslator.TypeKey[*strconv.NumError](), "a number translate").
+		SetFallbackTranslation(errortranslator.TypeKey[*minError](), "min type translate")
+
+	_, errNum := strconv.Atoi("abc")
+	translated, ok := ft.Translate(validate.ErrorMap{
+		"A": validate.Errors{validate.ErrRequired, errNum},
+		"B": validate.Errors{&minError{Min: 3}},
+	})
+
+	c.Assert(ok, Equals, true)
+	c.Assert(translated, DeepEquals, map[string]string{
+		"A": "a required translate, a number translate",
+		"B": "min type translate",
+	})
+}
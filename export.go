@@ -0,0 +1,70 @@
+package errortranslator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrNoErrorCode is returned when a translation cannot be exported because no code is registered for its error.
+var ErrNoErrorCode = errors.New("no error code registered")
+
+// ByCode returns the translations keyed by the code of the error (see CodeForError), the default translation is
+// stored under the DefaultCode.
+func (et ErrorTranslator) ByCode() (map[string]string, error) {
+	result := make(map[string]string, len(et))
+	for err, message := range et {
+		code, ok := CodeForError(err)
+		if !ok {
+			return nil, fmt.Errorf("%w for error `%v`", ErrNoErrorCode, err)
+		}
+		result[code] = message
+	}
+	return result, nil
+}
+
+// ByCode returns the translations per field keyed by the code of the error, this is the structure of a catalog file.
+func (ft FieldErrorTranslator) ByCode() (map[string]map[string]string, error) {
+	result := make(map[string]map[string]string, len(ft))
+	for field, et := range ft {
+		codes, err := et.ByCode()
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", field, err)
+		}
+		result[field] = codes
+	}
+	return result, nil
+}
+
+// ExportJSON writes the translator as JSON catalog, fields and codes are sorted so the output is stable.
+// Loading the written catalog with LoadJSON results in a equal translator.
+func ExportJSON(w io.Writer, ft FieldErrorTranslator) error {
+	catalog, err := ft.ByCode()
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(catalog)
+}
+
+// ExportYAML writes the translator as YAML catalog, fields and codes are sorted so the output is stable.
+// Loading the written catalog with LoadYAML results in a equal translator.
+func ExportYAML(w io.Writer, ft FieldErrorTranslator) error {
+	catalog, err := ft.ByCode()
+	if err != nil {
+		return err
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(catalog); err != nil {
+		return err
+	}
+	return enc.Close()
+}
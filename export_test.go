@@ -0,0 +1,120 @@
+package errortranslator_test
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"testing/fstest"
+
+	errortranslator "github.com/mbict/go-errortranslator"
+	validate "github.com/mbict/go-validate"
+	. "gopkg.in/check.v1"
+)
+
+type ExportSuite struct{}
+
+var _ = Suite(&ExportSuite{})
+
+var exportTranslator = errortranslator.FieldErrorTranslator{
+	"A": errortranslator.ErrorTranslator{
+		validate.ErrRequired: "A field is required",
+		validate.ErrMin:      "A field must be at least {min} <characters>",
+		nil:                  "A field has a error",
+	},
+	"B.1": errortranslator.ErrorTranslator{
+		errortranslator.TypeKey[*strconv.NumError](): "B must be a number",
+	},
+	"C": errortranslator.ErrorTranslator{},
+	"": errortranslator.ErrorTranslator{
+		validate.ErrRequired: "This is a required field",
+		errCustom:            "custom error",
+		nil:                  "There is a unknown error",
+	},
+}
+
+func (s *ExportSuite) TestCodeForError(c *C) {
+	code, ok := errortranslator.CodeForError(validate.ErrRequired)
+	c.Assert(code, Equals, "required")
+	c.Assert(ok, Equals, true)
+
+	code, ok = errortranslator.CodeForError(nil)
+	c.Assert(code, Equals, "default")
+	c.Assert(ok, Equals, true)
+
+	code, ok = errortranslator.CodeForError(errortranslator.TypeKey[*strconv.NumError]())
+	c.Assert(code, Equals, "number")
+	c.Assert(ok, Equals, true)
+
+	code, ok = errortranslator.CodeForError(errors.New("unregistered"))
+	c.Assert(code, Equals, "")
+	c.Assert(ok, Equals, false)
+}
+
+func (s *ExportSuite) TestExportJSON(c *C) {
+	var buf bytes.Buffer
+	err := errortranslator.ExportJSON(&buf, exportTranslator)
+
+	c.Assert(err, IsNil)
+	c.Assert(buf.String(), Equals, `{
+  "": {
+    "custom": "custom error",
+    "default": "There is a unknown error",
+    "required": "This is a required field"
+  },
+  "A": {
+    "default": "A field has a error",
+    "min": "A field must be at least {min} <characters>",
+    "required": "A field is required"
+  },
+  "B.1": {
+    "number": "B must be a number"
+  },
+  "C": {}
+}
+`)
+}
+
+func (s *ExportSuite) TestExportYAML(c *C) {
+	var buf bytes.Buffer
+	err := errortranslator.ExportYAML(&buf, exportTranslator)
+
+	c.Assert(err, IsNil)
+	c.Assert(buf.String(), Equals, `"":
+  custom: custom error
+  default: There is a unknown error
+  required: This is a required field
+A:
+  default: A field has a error
+  min: A field must be at least {min} <characters>
+  required: A field is required
+B.1:
+  number: B must be a number
+C: {}
+`)
+}
+
+func (s *ExportSuite) TestRoundTrip(c *C) {
+	var jsonBuf, yamlBuf bytes.Buffer
+	c.Assert(errortranslator.ExportJSON(&jsonBuf, exportTranslator), IsNil)
+	c.Assert(errortranslator.ExportYAML(&yamlBuf, exportTranslator), IsNil)
+
+	fsys := fstest.MapFS{
+		"catalog.json": {Data: jsonBuf.Bytes()},
+		"catalog.yaml": {Data: yamlBuf.Bytes()},
+	}
+
+	for _, name := range []string{"catalog.json", "catalog.yaml"} {
+		ft, err := errortranslator.LoadCatalog(fsys, name)
+
+		c.Assert(err, IsNil, Commentf(name))
+		c.Assert(ft, DeepEquals, exportTranslator, Commentf(name))
+	}
+}
+
+func (s *ExportSuite) TestExportUnregisteredError(c *C) {
+	ft := errortranslator.New().AddTranslation("A", errors.New("unregistered"), "message")
+
+	err := errortranslator.ExportJSON(&bytes.Buffer{}, ft)
+	c.Assert(err, ErrorMatches, "field \"A\": no error code registered for error `unregistered`")
+	c.Assert(errors.Is(err, errortranslator.ErrNoErrorCode), Equals, true)
+}
@@ -2,9 +2,11 @@ package errortranslator
 
 import (
 	validate "github.com/mbict/go-validate"
+	"golang.org/x/text/language"
 )
 
 // FieldErrorTranslator is a error translator that translates `errormaps` provided by the validator package.
+// Field names can be patterns with wildcards, `B.*` matches `B.1` and `B.2` (see Match).
 type FieldErrorTranslator map[string]ErrorTranslator
 
 // New creates a new Field error translator
@@ -45,49 +47,118 @@ func (ft FieldErrorTranslator) SetFallbackDefaultTranslation(message string) Fie
 	return ft.AddTranslation("", nil, message)
 }
 
+// Validate checks the translation messages of all fields and returns the first malformed message error found.
+func (ft FieldErrorTranslator) Validate() error {
+	for _, et := range ft {
+		if err := et.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Translate will translate a map (validate.ErrorMap) with errors (validate.Errors) into a human readable
-// message per field/map key.
+// message per field/map key. The {field} placeholder in a translation is replaced with the field name and the {label}
+// placeholder with the label of the field (see Labels).
 // If any of the provided error fields fail to find a translation, the function will return the map with the translated
 // errors and the second will be false indicated that we have a incomplete translation
 func (ft FieldErrorTranslator) Translate(errorMap validate.ErrorMap, fallback ...ErrorTranslator) (map[string]string, bool) {
-	return ft.translateErrorMap(errorMap, false, fallback)
+	return ft.translate(errorMap, false, fallback, Options{}, newPatternIndex(ft))
 }
 
 // TranslateFirst works the same as Translate but will stop after the first positive match is found per field entry.
 func (ft FieldErrorTranslator) TranslateFirst(errorMap validate.ErrorMap, fallback ...ErrorTranslator) (map[string]string, bool) {
-	return ft.translateErrorMap(errorMap, true, fallback)
+	return ft.translate(errorMap, true, fallback, Options{}, newPatternIndex(ft))
+}
+
+// TranslateDetails works the same as Translate but returns a Translation for every translated error of a field instead
+// of a single joined message. The translations of a field are in the order of the errors.
+func (ft FieldErrorTranslator) TranslateDetails(errorMap validate.ErrorMap, fallback ...ErrorTranslator) (map[string][]Translation, bool) {
+	return ft.translateErrorMap(errorMap, false, fallback, Options{}, newPatternIndex(ft))
+}
+
+// TranslateFirstDetails works the same as TranslateDetails but will stop after the first positive match is found per
+// field entry.
+func (ft FieldErrorTranslator) TranslateFirstDetails(errorMap validate.ErrorMap, fallback ...ErrorTranslator) (map[string][]Translation, bool) {
+	return ft.translateErrorMap(errorMap, true, fallback, Options{}, newPatternIndex(ft))
+}
+
+// translate translates the error map and joins the messages of a field with the joiner of the options.
+func (ft FieldErrorTranslator) translate(errorMap validate.ErrorMap, firstOnly bool, fallback []ErrorTranslator, opts Options, patterns patternIndex) (map[string]string, bool) {
+	translations, ok := ft.translateErrorMap(errorMap, firstOnly, fallback, opts, patterns)
+	return joinTranslationMap(opts.Joiner, language.Und, translations, ok)
+}
+
+// translateErrorMap translates the error map, patterns is the pattern index of the translator.
+func (ft FieldErrorTranslator) translateErrorMap(errorMap validate.ErrorMap, firstOnly bool, fallback []ErrorTranslator, opts Options, patterns patternIndex) (map[string][]Translation, bool) {
+	fallback = ft.withFieldDefaults(fallback)
+	labels := newPatternIndex(opts.Labels)
+	return translateErrorMap(errorMap, firstOnly, opts, language.Und, func(field string, err error) (Translation, bool) {
+		ctx := messageContext{field: field}
+		ctx.label, _ = opts.Labels.label(field, labels)
+		return ft.translateFieldError(ctx, err, fallback, opts, patterns)
+	})
+}
+
+// withFieldDefaults adds the default field translations as the last fallback
+func (ft FieldErrorTranslator) withFieldDefaults(fallback []ErrorTranslator) []ErrorTranslator {
+	if translations, hasDefault := ft[""]; hasDefault {
+		return append(fallback[:len(fallback):len(fallback)], translations)
+	}
+	return fallback
 }
 
-func (ft FieldErrorTranslator) translateErrorMap(errorMap validate.ErrorMap, firstOnly bool, fallback []ErrorTranslator) (map[string]string, bool) {
+// translateFieldError translates a single error of a field, the fallback should already contain the field defaults.
+// Less specific matching keys (see Match) are tried before the fallback.
+func (ft FieldErrorTranslator) translateFieldError(ctx messageContext, err error, fallback []ErrorTranslator, opts Options, patterns patternIndex) (Translation, bool) {
+	keys := ft.resolveKeys(ctx.field, opts, patterns)
+	chain := make([]ErrorTranslator, 0, len(keys)+len(fallback))
+	for _, key := range keys {
+		chain = append(chain, ft[key])
+	}
+	chain = append(chain, fallback...)
 
-	//add default field translations as the last fallback
-	translations, hasDefault := ft[""]
-	if hasDefault {
-		fallback = append(fallback, translations)
+	if len(chain) == 0 {
+		return Translation{}, false
 	}
 
-	result := make(map[string]string)
+	translation, i, ok := chain[0].translate(ctx, err, chain[1:])
+	if !ok {
+		return Translation{}, false
+	}
+
+	if i < len(keys) {
+		translation.MatchedKey = keys[i]
+	} else {
+		translation.UsedFallback = true
+	}
+	return translation, true
+}
+
+// translateErrorMap translates the errors of every field with the translate function.
+// The errors without translation are reported to the OnMissing hook of the options with the locale.
+// With SuppressDefault all errors are translated before the first is taken, a specific translation of a later error
+// has precedence over the default translation of the first error.
+func translateErrorMap(errorMap validate.ErrorMap, firstOnly bool, opts Options, locale language.Tag, translate func(field string, err error) (Translation, bool)) (map[string][]Translation, bool) {
+	result := make(map[string][]Translation)
 	allTranslated := true
 	for field, errs := range errorMap {
-		errTrans, ok := ft[field]
-		if !ok {
-			if len(fallback) == 0 {
-				allTranslated = false
-				continue
+		translations, ok := translateErrors(errs, firstOnly && !opts.SuppressDefault, func(err error) (Translation, bool) {
+			translation, ok := translate(field, err)
+			if !ok && opts.OnMissing != nil {
+				opts.OnMissing(Miss{Field: field, Err: err, Locale: locale})
 			}
-			errTrans = fallback[0]
-		}
+			return translation, ok
+		})
 
-		var message string
-		if firstOnly == true {
-			message, ok = errTrans.TranslateFirst(errs, fallback...)
-		} else {
-			message, ok = errTrans.Translate(errs, fallback...)
+		translations = opts.filter(translations)
+		if firstOnly && len(translations) > 1 {
+			translations = translations[:1]
 		}
 
 		allTranslated = allTranslated && ok
 		if ok {
-			result[field] = message
+			result[field] = translations
 		}
 	}
 	return result, allTranslated
@@ -1,6 +1,8 @@
 package errortranslator_test
 
 import (
+	"fmt"
+
 	errortranslator "github.com/mbict/go-errortranslator"
 	validate "github.com/mbict/go-validate"
 	. "gopkg.in/check.v1"
@@ -445,3 +447,25 @@ func (s *FieldErrorTranslatorSuite) TestTranslateFirstFallback(c *C) {
 		c.Assert(translated, DeepEquals, test.Expected, Commentf(test.Description))
 	}
 }
+
+func (s *FieldErrorTranslatorSuite) TestTranslateWrappedErrors(c *C) {
+	et := errortranslator.FieldErrorTranslator{
+		"A": errortranslator.ErrorTranslator{
+			validate.ErrRequired: "a required translate",
+		},
+		"": errortranslator.ErrorTranslator{
+			validate.ErrMin: "nil min default translate",
+		},
+	}
+
+	translated, ok := et.Translate(validate.ErrorMap{
+		"A": validate.Errors{fmt.Errorf("a: %w", validate.ErrRequired)},
+		"B": validate.Errors{fmt.Errorf("b: %w", validate.ErrMin)},
+	})
+
+	c.Assert(ok, Equals, true)
+	c.Assert(translated, DeepEquals, map[string]string{
+		"A": "a required translate",
+		"B": "nil min default translate",
+	})
+}
@@ -0,0 +1,23 @@
+module github.com/mbict/go-errortranslator/graphqlerror/gqlgen
+
+go 1.22.0
+
+require (
+	github.com/99designs/gqlgen v0.17.49
+	github.com/mbict/go-errortranslator v0.0.0-20261017190159-289bbeee9d05
+	github.com/vektah/gqlparser/v2 v2.5.16
+	gopkg.in/check.v1 v1.0.0-20201130134442-10cb98267c6c
+)
+
+require (
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/kr/pretty v0.2.1 // indirect
+	github.com/kr/text v0.1.0 // indirect
+	github.com/pelletier/go-toml/v2 v2.2.4 // indirect
+	github.com/sosodev/duration v1.3.1 // indirect
+	golang.org/x/text v0.22.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// build against the errortranslator module of this repository, consumers use the required version
+replace github.com/mbict/go-errortranslator => ../..
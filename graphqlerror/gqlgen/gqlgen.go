@@ -0,0 +1,55 @@
+// Package gqlgen adds translated validation errors to a gqlgen response.
+//
+// The package is a separate module that depends on github.com/99designs/gqlgen, the errortranslator module and the
+// graphqlerror package itself have no dependency on a GraphQL server library:
+//
+//	go get github.com/mbict/go-errortranslator/graphqlerror/gqlgen
+package gqlgen
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	errortranslator "github.com/mbict/go-errortranslator"
+	"github.com/mbict/go-errortranslator/graphqlerror"
+	validate "github.com/mbict/go-validate"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// AddErrors translates the error map and adds the errors to the response of the resolver.
+// The paths of the errors start with the path of the resolver.
+func AddErrors(ctx context.Context, translator errortranslator.DetailTranslator, errorMap validate.ErrorMap) {
+	var base []interface{}
+	for _, element := range graphql.GetPath(ctx) {
+		switch e := element.(type) {
+		case ast.PathIndex:
+			base = append(base, int(e))
+		case ast.PathName:
+			base = append(base, string(e))
+		}
+	}
+
+	for _, err := range graphqlerror.Errors(translator, base, errorMap) {
+		graphql.AddError(ctx, GQLError(err))
+	}
+}
+
+// GQLError converts the error into a gqlparser error
+func GQLError(err graphqlerror.Error) *gqlerror.Error {
+	path := make(ast.Path, 0, len(err.Path))
+	for _, segment := range err.Path {
+		switch s := segment.(type) {
+		case int:
+			path = append(path, ast.PathIndex(s))
+		case string:
+			path = append(path, ast.PathName(s))
+		}
+	}
+
+	return &gqlerror.Error{
+		Message:    err.Message,
+		Path:       path,
+		Extensions: err.Extensions,
+	}
+}
@@ -0,0 +1,40 @@
+package gqlgen_test
+
+import (
+	"testing"
+
+	"github.com/mbict/go-errortranslator/graphqlerror"
+	"github.com/mbict/go-errortranslator/graphqlerror/gqlgen"
+	"github.com/vektah/gqlparser/v2/ast"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) {
+	TestingT(t)
+}
+
+type GQLGenSuite struct{}
+
+var _ = Suite(&GQLGenSuite{})
+
+func (s *GQLGenSuite) TestGQLError(c *C) {
+	extensions := map[string]interface{}{"code": "min", "field": "items[0].price"}
+	err := gqlgen.GQLError(graphqlerror.Error{
+		Message:    "The price is too low",
+		Path:       []interface{}{"createOrder", "items", 0, "price"},
+		Extensions: extensions,
+	})
+
+	c.Assert(err.Message, Equals, "The price is too low")
+	c.Assert(err.Path, DeepEquals, ast.Path{ast.PathName("createOrder"), ast.PathName("items"), ast.PathIndex(0), ast.PathName("price")})
+	c.Assert(err.Extensions, DeepEquals, extensions)
+	c.Assert(err.Path.String(), Equals, "createOrder.items[0].price")
+}
+
+func (s *GQLGenSuite) TestGQLErrorWithoutPath(c *C) {
+	err := gqlgen.GQLError(graphqlerror.Error{Message: "required"})
+
+	c.Assert(err.Message, Equals, "required")
+	c.Assert(err.Path, HasLen, 0)
+	c.Assert(err.Extensions, IsNil)
+}
@@ -0,0 +1,93 @@
+// Package graphqlerror converts translated validation errors into GraphQL errors.
+//
+// Every error becomes a error object as described by the GraphQL specification, with a path derived from
+// the field name and the error code and field as extensions:
+//
+//	{
+//	  "message": "The price is too low",
+//	  "path": ["createOrder", "items", 0, "price"],
+//	  "extensions": {"code": "min", "field": "items[0].price"}
+//	}
+//
+// The types have no dependency on a GraphQL server library, see the gqlgen package for a gqlgen adapter.
+package graphqlerror
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	errortranslator "github.com/mbict/go-errortranslator"
+	validate "github.com/mbict/go-validate"
+)
+
+// Error is a GraphQL error object
+type Error struct {
+	Message    string                 `json:"message"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+func (e Error) Error() string {
+	return e.Message
+}
+
+// Errors translates the error map into GraphQL errors, a error for every error of the error map.
+// The path of a error is the base path, usually the path of the resolver, followed by the segments of the field name.
+// The errors are sorted by field and keep the order of the errors within a field.
+// The extensions hold the error code (when registered, see errortranslator.CodeForError) and the field name.
+// A untranslated error has the error code as message.
+func Errors(translator errortranslator.DetailTranslator, base []interface{}, errorMap validate.ErrorMap) []Error {
+	translations, _ := translator.TranslateDetails(errorMap)
+	translations = errortranslator.WithUntranslated(errorMap, translations)
+
+	fields := make([]string, 0, len(translations))
+	for field := range translations {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var errs []Error
+	for _, field := range fields {
+		path := append(base[:len(base):len(base)], Path(field)...)
+		for _, translation := range translations[field] {
+			extensions := map[string]interface{}{
+				"field": field,
+			}
+			if translation.Code != "" {
+				extensions["code"] = translation.Code
+			}
+
+			message := translation.Message
+			if message == "" {
+				message = translation.Code
+			}
+
+			errs = append(errs, Error{
+				Message:    message,
+				Path:       path,
+				Extensions: extensions,
+			})
+		}
+	}
+	return errs
+}
+
+// Path converts a field name into a GraphQL response path. Dots and brackets separate the segments and numeric
+// segments are list indexes: `items[0].price` becomes ["items", 0, "price"]. The empty field name has no path.
+func Path(field string) []interface{} {
+	if field == "" {
+		return nil
+	}
+
+	segments := strings.Split(strings.NewReplacer("[", ".", "]", "").Replace(field), ".")
+	path := make([]interface{}, 0, len(segments))
+	for _, segment := range segments {
+		if index, err := strconv.Atoi(segment); err == nil && index >= 0 {
+			path = append(path, index)
+			continue
+		}
+		path = append(path, segment)
+	}
+	return path
+}
@@ -0,0 +1,102 @@
+package graphqlerror_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	errortranslator "github.com/mbict/go-errortranslator"
+	"github.com/mbict/go-errortranslator/graphqlerror"
+	validate "github.com/mbict/go-validate"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) {
+	TestingT(t)
+}
+
+type GraphQLErrorSuite struct{}
+
+var _ = Suite(&GraphQLErrorSuite{})
+
+func (s *GraphQLErrorSuite) TestPath(c *C) {
+	tests := []struct {
+		Field    string
+		Expected []interface{}
+	}{
+		{Field: "", Expected: nil},
+		{Field: "name", Expected: []interface{}{"name"}},
+		{Field: "address.street", Expected: []interface{}{"address", "street"}},
+		{Field: "items[0].price", Expected: []interface{}{"items", 0, "price"}},
+		{Field: "items.1", Expected: []interface{}{"items", 1}},
+	}
+
+	for _, test := range tests {
+		c.Assert(graphqlerror.Path(test.Field), DeepEquals, test.Expected, Commentf(test.Field))
+	}
+}
+
+func (s *GraphQLErrorSuite) TestErrors(c *C) {
+	ft := errortranslator.New().
+		AddTranslation("items[*].price", validate.ErrMin, "The price is too low").
+		SetFallbackTranslation(validate.ErrRequired, "The {field} is required").
+		SetFallbackTranslation(validate.ErrMax, "The {field} is too long")
+
+	base := []interface{}{"createOrder"}
+	errs := graphqlerror.Errors(ft, base, validate.ErrorMap{
+		"items[0].price": validate.Errors{validate.ErrMin},
+		"customer":       validate.Errors{validate.ErrRequired, validate.ErrMax},
+	})
+
+	c.Assert(errs, DeepEquals, []graphqlerror.Error{
+		{
+			Message:    "The customer is required",
+			Path:       []interface{}{"createOrder", "customer"},
+			Extensions: map[string]interface{}{"code": "required", "field": "customer"},
+		},
+		{
+			Message:    "The customer is too long",
+			Path:       []interface{}{"createOrder", "customer"},
+			Extensions: map[string]interface{}{"code": "max", "field": "customer"},
+		},
+		{
+			Message:    "The price is too low",
+			Path:       []interface{}{"createOrder", "items", 0, "price"},
+			Extensions: map[string]interface{}{"code": "min", "field": "items[0].price"},
+		},
+	})
+
+	//the base path is not modified
+	c.Assert(base, DeepEquals, []interface{}{"createOrder"})
+
+	data, err := json.Marshal(errs[2])
+	c.Assert(err, IsNil)
+	c.Assert(string(data), Equals, `{"message":"The price is too low","path":["createOrder","items",0,"price"],`+
+		`"extensions":{"code":"min","field":"items[0].price"}}`)
+}
+
+func (s *GraphQLErrorSuite) TestErrorsUntranslated(c *C) {
+	ft := errortranslator.New().SetFallbackTranslation(validate.ErrRequired, "required")
+
+	errs := graphqlerror.Errors(ft, nil, validate.ErrorMap{
+		"name": validate.Errors{validate.ErrRequired, validate.ErrMin},
+		"age":  validate.Errors{validate.ErrMax},
+	})
+
+	c.Assert(errs, DeepEquals, []graphqlerror.Error{
+		{
+			Message:    "max",
+			Path:       []interface{}{"age"},
+			Extensions: map[string]interface{}{"code": "max", "field": "age"},
+		},
+		{
+			Message:    "required",
+			Path:       []interface{}{"name"},
+			Extensions: map[string]interface{}{"code": "required", "field": "name"},
+		},
+		{
+			Message:    "min",
+			Path:       []interface{}{"name"},
+			Extensions: map[string]interface{}{"code": "min", "field": "name"},
+		},
+	})
+}
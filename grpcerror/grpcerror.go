@@ -0,0 +1,113 @@
+// Package grpcerror converts translated validation errors into a gRPC status.
+//
+// The status has the code InvalidArgument with a google.rpc.BadRequest detail that holds a field violation per
+// error, and a google.rpc.LocalizedMessage detail with all the messages in the language of the translator.
+// Handlers can return a ValidationError, the interceptors translate it into the language of the request.
+package grpcerror
+
+import (
+	"sort"
+	"strings"
+
+	errortranslator "github.com/mbict/go-errortranslator"
+	validate "github.com/mbict/go-validate"
+	"golang.org/x/text/language"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// ValidationError is a error that holds the errors of a validation, handlers return it to let the interceptors
+// translate the errors into a InvalidArgument status.
+type ValidationError struct {
+	Errors validate.ErrorMap
+}
+
+// NewValidationError creates a new ValidationError with the errors
+func NewValidationError(errorMap validate.ErrorMap) *ValidationError {
+	return &ValidationError{Errors: errorMap}
+}
+
+func (e *ValidationError) Error() string {
+	return "validation failed"
+}
+
+// Status translates the error map into a InvalidArgument status. Every error is added as field violation with the
+// field name, the translated message as description and the error code in upper snake case as reason, a untranslated
+// error has no description. The field violations are sorted by field and keep the order of the errors within a field.
+// The translated messages are added as localized message when the locale is not language.Und, joined with the joiner
+// of the translator when it has one (see errortranslator.Translator.Joiner) or errortranslator.DefaultJoiner.
+func Status(translator errortranslator.DetailTranslator, locale language.Tag, errorMap validate.ErrorMap) *status.Status {
+	translations, _ := translator.TranslateDetails(errorMap)
+	translations = errortranslator.WithUntranslated(errorMap, translations)
+
+	fields := make([]string, 0, len(translations))
+	for field := range translations {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var (
+		violations []*errdetails.BadRequest_FieldViolation
+		messages   []string
+	)
+	for _, field := range fields {
+		for _, translation := range translations[field] {
+			violation := &errdetails.BadRequest_FieldViolation{
+				Field:       field,
+				Description: translation.Message,
+				Reason:      reason(translation.Code),
+			}
+
+			if translation.Message != "" {
+				if locale != language.Und {
+					violation.LocalizedMessage = &errdetails.LocalizedMessage{
+						Locale:  locale.String(),
+						Message: translation.Message,
+					}
+				}
+				messages = append(messages, translation.Message)
+			}
+
+			violations = append(violations, violation)
+		}
+	}
+
+	st := status.New(codes.InvalidArgument, "validation failed")
+
+	details := []protoadapt.MessageV1{&errdetails.BadRequest{FieldViolations: violations}}
+	if locale != language.Und && len(messages) > 0 {
+		details = append(details, &errdetails.LocalizedMessage{
+			Locale:  locale.String(),
+			Message: joiner(translator).Join(locale, messages),
+		})
+	}
+
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		return withDetails
+	}
+	return st
+}
+
+// joiner returns the joiner of the translator, errortranslator.DefaultJoiner when the translator has no joiner
+func joiner(translator errortranslator.DetailTranslator) errortranslator.Joiner {
+	if t, ok := translator.(interface{ Joiner() errortranslator.Joiner }); ok {
+		return t.Joiner()
+	}
+	return errortranslator.DefaultJoiner
+}
+
+// reason converts a error code into a reason in upper snake case, `min-length` becomes `MIN_LENGTH`
+func reason(code string) string {
+	if code == "" {
+		return ""
+	}
+
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == '.' || r == ' ' {
+			return '_'
+		}
+		return r
+	}, strings.ToUpper(code))
+}
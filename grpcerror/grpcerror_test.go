@@ -0,0 +1,204 @@
+package grpcerror_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	errortranslator "github.com/mbict/go-errortranslator"
+	"github.com/mbict/go-errortranslator/grpcerror"
+	validate "github.com/mbict/go-validate"
+	"golang.org/x/text/language"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) {
+	TestingT(t)
+}
+
+type GRPCErrorSuite struct{}
+
+var _ = Suite(&GRPCErrorSuite{})
+
+var errorMap = validate.ErrorMap{
+	"service":    validate.Errors{validate.ErrRequired, validate.ErrMax},
+	"options[0]": validate.Errors{validate.ErrMin},
+}
+
+func newLocaleTranslator() *errortranslator.LocaleTranslator {
+	lt := errortranslator.NewLocaleTranslator(language.English)
+	lt.Locale(language.English).
+		SetFallbackTranslation(validate.ErrRequired, "The {field} is required").
+		SetFallbackTranslation(validate.ErrMin, "The {field} is too short").
+		SetFallbackTranslation(validate.ErrMax, "The {field} is too long")
+	lt.Locale(language.Dutch).
+		SetFallbackTranslation(validate.ErrRequired, "{field} is verplicht")
+	return lt
+}
+
+// details returns the bad request and localized message details of the status
+func details(c *C, st *status.Status) (*errdetails.BadRequest, *errdetails.LocalizedMessage) {
+	var (
+		badRequest *errdetails.BadRequest
+		localized  *errdetails.LocalizedMessage
+	)
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.BadRequest:
+			badRequest = d
+		case *errdetails.LocalizedMessage:
+			localized = d
+		default:
+			c.Fatalf("unexpected detail %T", detail)
+		}
+	}
+	c.Assert(badRequest, NotNil)
+	return badRequest, localized
+}
+
+func violations(badRequest *errdetails.BadRequest) [][3]string {
+	var result [][3]string
+	for _, v := range badRequest.GetFieldViolations() {
+		result = append(result, [3]string{v.GetField(), v.GetReason(), v.GetDescription()})
+	}
+	return result
+}
+
+func (s *GRPCErrorSuite) TestStatus(c *C) {
+	lt := newLocaleTranslator()
+	st := grpcerror.Status(lt.ForLocale(language.Dutch), language.Dutch, errorMap)
+
+	c.Assert(st.Code(), Equals, codes.InvalidArgument)
+
+	badRequest, localized := details(c, st)
+	c.Assert(violations(badRequest), DeepEquals, [][3]string{
+		{"options[0]", "MIN", "The options[0] is too short"},
+		{"service", "REQUIRED", "service is verplicht"},
+		{"service", "MAX", "The service is too long"},
+	})
+	c.Assert(badRequest.GetFieldViolations()[1].GetLocalizedMessage().GetLocale(), Equals, "nl")
+	c.Assert(localized.GetLocale(), Equals, "nl")
+	c.Assert(localized.GetMessage(), Equals, "The options[0] is too short, service is verplicht, The service is too long")
+}
+
+func (s *GRPCErrorSuite) TestStatusWithoutLocale(c *C) {
+	ft := errortranslator.New().SetFallbackTranslation(validate.ErrRequired, "required")
+	st := grpcerror.Status(ft, language.Und, validate.ErrorMap{"name": validate.Errors{validate.ErrRequired}})
+
+	badRequest, localized := details(c, st)
+	c.Assert(localized, IsNil)
+	c.Assert(violations(badRequest), DeepEquals, [][3]string{{"name", "REQUIRED", "required"}})
+	c.Assert(badRequest.GetFieldViolations()[0].GetLocalizedMessage(), IsNil)
+}
+
+func (s *GRPCErrorSuite) TestStatusUntranslated(c *C) {
+	ft := errortranslator.New().SetFallbackTranslation(validate.ErrRequired, "required")
+	st := grpcerror.Status(ft, language.English, validate.ErrorMap{
+		"name": validate.Errors{validate.ErrRequired, validate.ErrMin},
+		"age":  validate.Errors{validate.ErrMax},
+	})
+
+	badRequest, localized := details(c, st)
+	c.Assert(violations(badRequest), DeepEquals, [][3]string{
+		{"age", "MAX", ""},
+		{"name", "REQUIRED", "required"},
+		{"name", "MIN", ""},
+	})
+	c.Assert(badRequest.GetFieldViolations()[0].GetLocalizedMessage(), IsNil)
+	c.Assert(localized.GetMessage(), Equals, "required")
+}
+
+func (s *GRPCErrorSuite) TestStatusJoiner(c *C) {
+	lt := newLocaleTranslator()
+	lt.SetOptions(errortranslator.Options{Joiner: errortranslator.SeparatorJoiner("; ")})
+	st := grpcerror.Status(lt.ForLocale(language.Dutch), language.Dutch, errorMap)
+
+	_, localized := details(c, st)
+	c.Assert(localized.GetMessage(), Equals, "The options[0] is too short; service is verplicht; The service is too long")
+}
+
+// healthServer returns validation errors for every call, except for the denied service
+type healthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+}
+
+func (h *healthServer) Check(_ context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	if req.GetService() == "denied" {
+		return nil, status.Error(codes.PermissionDenied, "denied")
+	}
+	return nil, grpcerror.NewValidationError(errorMap)
+}
+
+func (h *healthServer) Watch(*grpc_health_v1.HealthCheckRequest, grpc_health_v1.Health_WatchServer) error {
+	return grpcerror.NewValidationError(errorMap)
+}
+
+// dial starts a server with the interceptors on a in memory connection
+func dial(c *C) (grpc_health_v1.HealthClient, func()) {
+	lt := newLocaleTranslator()
+	lis := bufconn.Listen(1024 * 1024)
+
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(grpcerror.UnaryServerInterceptor(lt)),
+		grpc.StreamInterceptor(grpcerror.StreamServerInterceptor(lt)),
+	)
+	grpc_health_v1.RegisterHealthServer(server, &healthServer{})
+	go server.Serve(lis)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	c.Assert(err, IsNil)
+
+	return grpc_health_v1.NewHealthClient(conn), func() {
+		conn.Close()
+		server.Stop()
+	}
+}
+
+func (s *GRPCErrorSuite) TestUnaryServerInterceptor(c *C) {
+	client, stop := dial(c)
+	defer stop()
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "accept-language", "nl-BE, en;q=0.5")
+	_, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+
+	st := status.Convert(err)
+	c.Assert(st.Code(), Equals, codes.InvalidArgument)
+
+	badRequest, localized := details(c, st)
+	c.Assert(violations(badRequest), HasLen, 3)
+	c.Assert(localized.GetLocale(), Equals, "nl")
+
+	//other errors are not changed
+	_, err = client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: "denied"})
+	c.Assert(status.Code(err), Equals, codes.PermissionDenied)
+}
+
+func (s *GRPCErrorSuite) TestStreamServerInterceptor(c *C) {
+	client, stop := dial(c)
+	defer stop()
+
+	stream, err := client.Watch(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	c.Assert(err, IsNil)
+
+	_, err = stream.Recv()
+
+	st := status.Convert(err)
+	c.Assert(st.Code(), Equals, codes.InvalidArgument)
+
+	_, localized := details(c, st)
+	c.Assert(localized.GetLocale(), Equals, "en")
+	c.Assert(localized.GetMessage(), Equals, "The options[0] is too short, The service is required, The service is too long")
+}
@@ -0,0 +1,62 @@
+package grpcerror
+
+import (
+	"context"
+	"errors"
+
+	errortranslator "github.com/mbict/go-errortranslator"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// acceptLanguageKeys are the metadata keys that hold the Accept-Language, the second one is set by grpc-gateway
+var acceptLanguageKeys = []string{"accept-language", "grpcgateway-accept-language"}
+
+// UnaryServerInterceptor returns a interceptor that converts a ValidationError returned by the handler into a
+// InvalidArgument status (see Status). The language is selected with the accept-language metadata of the request.
+func UnaryServerInterceptor(lt *errortranslator.LocaleTranslator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			err = convert(ctx, lt, err)
+		}
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor returns a interceptor that converts a ValidationError returned by the handler into a
+// InvalidArgument status (see Status). The language is selected with the accept-language metadata of the stream.
+func StreamServerInterceptor(lt *errortranslator.LocaleTranslator) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := handler(srv, ss)
+		if err != nil {
+			err = convert(ss.Context(), lt, err)
+		}
+		return err
+	}
+}
+
+// convert translates a ValidationError into a status error, other errors are returned as is
+func convert(ctx context.Context, lt *errortranslator.LocaleTranslator, err error) error {
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		return err
+	}
+
+	t := lt.ForLocale(lt.MatchAcceptLanguage(acceptLanguage(ctx)))
+	return Status(t, t.Locale(), verr.Errors).Err()
+}
+
+func acceptLanguage(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	for _, key := range acceptLanguageKeys {
+		if values := md.Get(key); len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,134 @@
+// Package htmlform makes translated validation errors available to html/template.
+//
+// FormErrors holds the translated messages per field, the methods can be called from a template:
+//
+//	<input name="address[street]" class="{{.Errors.CSSClass "address.street"}}">
+//	{{if .Errors.Has "address.street"}}<span>{{.Errors.First "address.street"}}</span>{{end}}
+//
+// Nested field names can be written with dots or brackets, `address.street`, `address[street]` and
+// `items[0][price]` refer to the same fields as `address.street` and `items.0.price`.
+package htmlform
+
+import (
+	"html"
+	"html/template"
+	"strings"
+
+	errortranslator "github.com/mbict/go-errortranslator"
+	validate "github.com/mbict/go-validate"
+)
+
+// DefaultCSSClass is the css class returned by CSSClass for fields with errors when no class is set.
+const DefaultCSSClass = "is-invalid"
+
+// FormErrors holds the translated messages of a form per field.
+// A nil FormErrors has no errors, a template can be rendered before the form is submitted.
+type FormErrors struct {
+	// Class is the css class returned by CSSClass for fields with errors, DefaultCSSClass when empty.
+	Class string
+
+	messages map[string][]string
+	invalid  map[string]bool
+}
+
+// New translates the error map and returns the messages per field.
+// A field with untranslated errors has errors but no message for the untranslated errors.
+func New(translator errortranslator.DetailTranslator, errorMap validate.ErrorMap) *FormErrors {
+	translations, _ := translator.TranslateDetails(errorMap)
+	translations = errortranslator.WithUntranslated(errorMap, translations)
+
+	fe := &FormErrors{
+		messages: make(map[string][]string, len(translations)),
+		invalid:  make(map[string]bool, len(translations)),
+	}
+	for field, t := range translations {
+		key := normalize(field)
+		for _, translation := range t {
+			fe.invalid[key] = true
+			if translation.Message != "" {
+				fe.messages[key] = append(fe.messages[key], translation.Message)
+			}
+		}
+	}
+	return fe
+}
+
+// Has reports if the field has errors, also when the errors are not translated
+func (fe *FormErrors) Has(field string) bool {
+	return fe != nil && fe.invalid[normalize(field)]
+}
+
+// First returns the first message of the field, a empty string when the field has no errors
+func (fe *FormErrors) First(field string) string {
+	messages := fe.All(field)
+	if len(messages) == 0 {
+		return ""
+	}
+	return messages[0]
+}
+
+// All returns all the messages of the field
+func (fe *FormErrors) All(field string) []string {
+	if fe == nil {
+		return nil
+	}
+	return fe.messages[normalize(field)]
+}
+
+// CSSClass returns the css class for a field with errors, a empty string when the field has no errors.
+func (fe *FormErrors) CSSClass(field string) string {
+	if !fe.Has(field) {
+		return ""
+	}
+
+	if fe.Class == "" {
+		return DefaultCSSClass
+	}
+	return fe.Class
+}
+
+// Empty reports if the form has no errors at all
+func (fe *FormErrors) Empty() bool {
+	return fe == nil || len(fe.invalid) == 0
+}
+
+// List returns the messages of the field as HTML list, the messages are escaped.
+// A empty string is returned when the field has no errors.
+func (fe *FormErrors) List(field string) template.HTML {
+	messages := fe.All(field)
+	if len(messages) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(`<ul class="errors">`)
+	for _, message := range messages {
+		b.WriteString("<li>")
+		b.WriteString(html.EscapeString(message))
+		b.WriteString("</li>")
+	}
+	b.WriteString("</ul>")
+	return template.HTML(b.String())
+}
+
+// FuncMap returns the template functions hasError, firstError, allErrors, errorClass and errorList.
+// The functions take the FormErrors as first argument: {{firstError .Errors "email"}}
+func FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"hasError":   (*FormErrors).Has,
+		"firstError": (*FormErrors).First,
+		"allErrors":  (*FormErrors).All,
+		"errorClass": (*FormErrors).CSSClass,
+		"errorList":  (*FormErrors).List,
+	}
+}
+
+// normalize converts a field name with brackets into the dotted notation, `items[0][price]` becomes `items.0.price`
+func normalize(field string) string {
+	if !strings.ContainsAny(field, "[]") {
+		return field
+	}
+
+	field = strings.NewReplacer("][", ".", "[", ".", "]", "").Replace(field)
+	return strings.ReplaceAll(field, "..", ".")
+}
@@ -0,0 +1,119 @@
+package htmlform_test
+
+import (
+	"html/template"
+	"strings"
+	"testing"
+
+	errortranslator "github.com/mbict/go-errortranslator"
+	"github.com/mbict/go-errortranslator/htmlform"
+	validate "github.com/mbict/go-validate"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) {
+	TestingT(t)
+}
+
+type HTMLFormSuite struct{}
+
+var _ = Suite(&HTMLFormSuite{})
+
+var translations = errortranslator.New().
+	AddTranslation("email", validate.ErrRequired, "The email is required").
+	AddTranslation("email", validate.ErrMin, "The email must contain <at least> 3 characters").
+	SetFallbackTranslation(validate.ErrRequired, "The {field} is required")
+
+func newFormErrors() *htmlform.FormErrors {
+	return htmlform.New(translations, validate.ErrorMap{
+		"email":          validate.Errors{validate.ErrRequired, validate.ErrMin},
+		"address.street": validate.Errors{validate.ErrRequired},
+		"items[0].price": validate.Errors{validate.ErrRequired},
+	})
+}
+
+func (s *HTMLFormSuite) TestFormErrors(c *C) {
+	fe := newFormErrors()
+
+	c.Assert(fe.Empty(), Equals, false)
+	c.Assert(fe.Has("email"), Equals, true)
+	c.Assert(fe.Has("name"), Equals, false)
+	c.Assert(fe.First("email"), Equals, "The email is required")
+	c.Assert(fe.First("name"), Equals, "")
+	c.Assert(fe.All("email"), DeepEquals, []string{"The email is required", "The email must contain <at least> 3 characters"})
+	c.Assert(fe.CSSClass("email"), Equals, htmlform.DefaultCSSClass)
+	c.Assert(fe.CSSClass("name"), Equals, "")
+
+	fe.Class = "has-error"
+	c.Assert(fe.CSSClass("email"), Equals, "has-error")
+}
+
+func (s *HTMLFormSuite) TestUntranslatedErrors(c *C) {
+	fe := htmlform.New(translations, validate.ErrorMap{
+		"name":  validate.Errors{validate.ErrMax},
+		"email": validate.Errors{validate.ErrMin, validate.ErrMax},
+	})
+
+	c.Assert(fe.Empty(), Equals, false)
+	c.Assert(fe.Has("name"), Equals, true)
+	c.Assert(fe.CSSClass("name"), Equals, htmlform.DefaultCSSClass)
+	c.Assert(fe.All("name"), HasLen, 0)
+	c.Assert(fe.List("name"), Equals, template.HTML(""))
+	c.Assert(fe.All("email"), DeepEquals, []string{"The email must contain <at least> 3 characters"})
+}
+
+func (s *HTMLFormSuite) TestNestedFields(c *C) {
+	fe := newFormErrors()
+
+	for _, field := range []string{"address.street", "address[street]"} {
+		c.Assert(fe.First(field), Equals, "The address.street is required", Commentf(field))
+	}
+
+	for _, field := range []string{"items[0].price", "items[0][price]", "items.0.price"} {
+		c.Assert(fe.First(field), Equals, "The items[0].price is required", Commentf(field))
+	}
+}
+
+func (s *HTMLFormSuite) TestNilFormErrors(c *C) {
+	var fe *htmlform.FormErrors
+
+	c.Assert(fe.Empty(), Equals, true)
+	c.Assert(fe.Has("email"), Equals, false)
+	c.Assert(fe.First("email"), Equals, "")
+	c.Assert(fe.CSSClass("email"), Equals, "")
+	c.Assert(fe.List("email"), Equals, template.HTML(""))
+}
+
+func (s *HTMLFormSuite) TestTemplate(c *C) {
+	tmpl := template.Must(template.New("form").Parse(
+		`<input name="email" class="{{.Errors.CSSClass "email"}}">` +
+			`{{if .Errors.Has "email"}}<span>{{.Errors.First "email"}}</span>{{end}}` +
+			`{{.Errors.List "email"}}`))
+
+	var b strings.Builder
+	err := tmpl.Execute(&b, map[string]interface{}{"Errors": newFormErrors()})
+
+	c.Assert(err, IsNil)
+	c.Assert(b.String(), Equals, `<input name="email" class="is-invalid"><span>The email is required</span>`+
+		`<ul class="errors"><li>The email is required</li><li>The email must contain &lt;at least&gt; 3 characters</li></ul>`)
+
+	//a template without errors
+	b.Reset()
+	err = tmpl.Execute(&b, map[string]interface{}{"Errors": (*htmlform.FormErrors)(nil)})
+
+	c.Assert(err, IsNil)
+	c.Assert(b.String(), Equals, `<input name="email" class="">`)
+}
+
+func (s *HTMLFormSuite) TestFuncMap(c *C) {
+	tmpl := template.Must(template.New("form").Funcs(htmlform.FuncMap()).Parse(
+		`{{if hasError .Errors "email"}}{{errorClass .Errors "email"}}|{{firstError .Errors "email"}}{{end}}` +
+			`{{range allErrors .Errors "address[street]"}}|{{.}}{{end}}` +
+			`{{errorList .Errors "name"}}`))
+
+	var b strings.Builder
+	err := tmpl.Execute(&b, map[string]interface{}{"Errors": newFormErrors()})
+
+	c.Assert(err, IsNil)
+	c.Assert(b.String(), Equals, `is-invalid|The email is required|The address.street is required`)
+}
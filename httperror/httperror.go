@@ -0,0 +1,187 @@
+// Package httperror writes translated validation errors as HTTP responses.
+//
+// The Middleware selects the language of the request with the Accept-Language header and stores a translator for the
+// language in the request context. Handlers write the errors with WriteValidationError, the response format is
+// negotiated with the Accept header: JSON, problem details (see the problem package) or plain text.
+//
+//	http.Handle("/users", httperror.Middleware(localeTranslator)(usersHandler))
+//
+//	func usersHandler(w http.ResponseWriter, r *http.Request) {
+//		if errorMap := validator.Validate(user); len(errorMap) > 0 {
+//			httperror.WriteValidationError(w, r, errorMap)
+//			return
+//		}
+//		...
+//	}
+package httperror
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	errortranslator "github.com/mbict/go-errortranslator"
+	"github.com/mbict/go-errortranslator/problem"
+	validate "github.com/mbict/go-validate"
+	"golang.org/x/text/language"
+)
+
+// ErrNoTranslator is returned when there is no translator in the request context.
+var ErrNoTranslator = errors.New("httperror: no translator in request context")
+
+// Media types that can be written
+const (
+	ContentTypeJSON    = "application/json"
+	ContentTypeProblem = problem.ContentType
+	ContentTypeText    = "text/plain"
+)
+
+// Translator translates a error map into the translations per field, the messages of a field are joined with the
+// joiner of the translator when it has a Joiner method.
+// errortranslator.FieldErrorTranslator, *errortranslator.Translator, *errortranslator.SyncTranslator and
+// *errortranslator.LocalizedTranslator implement this interface.
+type Translator interface {
+	errortranslator.DetailTranslator
+}
+
+type contextKey struct{}
+
+type contextValue struct {
+	translator Translator
+	locale     language.Tag
+}
+
+// WithTranslator returns a copy of the context with the translator, the locale is the language of the translations
+// and is written as Content-Language header. Use language.Und when the language is unknown.
+func WithTranslator(ctx context.Context, translator Translator, locale language.Tag) context.Context {
+	return context.WithValue(ctx, contextKey{}, contextValue{translator: translator, locale: locale})
+}
+
+// FromContext returns the translator and the language stored in the context.
+func FromContext(ctx context.Context) (Translator, language.Tag, bool) {
+	v, ok := ctx.Value(contextKey{}).(contextValue)
+	return v.translator, v.locale, ok
+}
+
+// Middleware returns a middleware that stores a translator for the language that matches the Accept-Language header
+// of the request best in the request context.
+func Middleware(lt *errortranslator.LocaleTranslator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t := lt.ForLocale(lt.MatchAcceptLanguage(r.Header.Get("Accept-Language")))
+			next.ServeHTTP(w, r.WithContext(WithTranslator(r.Context(), t, t.Locale())))
+		})
+	}
+}
+
+// WriteValidationError translates the error map with the translator of the request context and writes it with the
+// status 422 Unprocessable Entity. The format is negotiated with the Accept header of the request:
+//   - application/json, the default: a JSON object with the message per field
+//   - application/problem+json: a problem details object (see the problem package)
+//   - text/plain: a line per field, `field: message`, sorted by field
+//
+// Every error of the error map is written in all formats, a untranslated error has its code as message (see
+// errortranslator.CodeForError). The response varies on the Accept and Accept-Language headers of the request.
+//
+// ErrNoTranslator is returned when there is no translator in the context, nothing is written in that case.
+func WriteValidationError(w http.ResponseWriter, r *http.Request, errorMap validate.ErrorMap) error {
+	translator, locale, ok := FromContext(r.Context())
+	if !ok {
+		return ErrNoTranslator
+	}
+
+	w.Header().Add("Vary", "Accept, Accept-Language")
+	if locale != language.Und {
+		w.Header().Set("Content-Language", locale.String())
+	}
+
+	switch Negotiate(r.Header.Get("Accept")) {
+	case ContentTypeProblem:
+		return problem.New(translator).Write(w, errorMap)
+	case ContentTypeText:
+		return writeText(w, translator, locale, errorMap)
+	}
+	return writeJSON(w, translator, locale, errorMap)
+}
+
+func writeJSON(w http.ResponseWriter, translator Translator, locale language.Tag, errorMap validate.ErrorMap) error {
+	messages := translate(translator, locale, errorMap)
+	data, err := json.Marshal(messages)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", ContentTypeJSON)
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	_, err = w.Write(data)
+	return err
+}
+
+func writeText(w http.ResponseWriter, translator Translator, locale language.Tag, errorMap validate.ErrorMap) error {
+	messages := translate(translator, locale, errorMap)
+
+	fields := make([]string, 0, len(messages))
+	for field := range messages {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var b strings.Builder
+	for _, field := range fields {
+		if field == "" {
+			fmt.Fprintf(&b, "%s\n", messages[field])
+			continue
+		}
+		fmt.Fprintf(&b, "%s: %s\n", field, messages[field])
+	}
+
+	w.Header().Set("Content-Type", ContentTypeText+"; charset=utf-8")
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	_, err := w.Write([]byte(b.String()))
+	return err
+}
+
+// translate translates the error map, the untranslated errors get their code as message so every error is written
+// like in the problem details. The messages of a field are joined with the joiner of the translator.
+func translate(translator Translator, locale language.Tag, errorMap validate.ErrorMap) map[string]string {
+	translations, _ := translator.TranslateDetails(errorMap)
+
+	messages := make(map[string]string, len(errorMap))
+	for field, translations := range errortranslator.WithUntranslated(errorMap, translations) {
+		var parts []string
+		for _, translation := range translations {
+			if translation.Message != "" {
+				parts = append(parts, translation.Message)
+				continue
+			}
+
+			//errors left out by the Dedupe and SuppressDefault options are translated on their own
+			if _, ok := translator.TranslateDetails(validate.ErrorMap{field: validate.Errors{translation.Err}}); ok {
+				continue
+			}
+
+			code := translation.Code
+			if code == "" {
+				code = translation.Err.Error()
+			}
+			parts = append(parts, code)
+		}
+
+		if len(parts) > 0 {
+			messages[field] = joiner(translator).Join(locale, parts)
+		}
+	}
+	return messages
+}
+
+// joiner returns the joiner of the translator, errortranslator.DefaultJoiner when the translator has no joiner
+func joiner(translator Translator) errortranslator.Joiner {
+	if t, ok := translator.(interface{ Joiner() errortranslator.Joiner }); ok {
+		return t.Joiner()
+	}
+	return errortranslator.DefaultJoiner
+}
@@ -0,0 +1,151 @@
+package httperror_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	errortranslator "github.com/mbict/go-errortranslator"
+	"github.com/mbict/go-errortranslator/httperror"
+	validate "github.com/mbict/go-validate"
+	"golang.org/x/text/language"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) {
+	TestingT(t)
+}
+
+type HTTPErrorSuite struct{}
+
+var _ = Suite(&HTTPErrorSuite{})
+
+var errorMap = validate.ErrorMap{
+	"name":  validate.Errors{validate.ErrRequired},
+	"email": validate.Errors{validate.ErrRequired},
+}
+
+func newLocaleTranslator() *errortranslator.LocaleTranslator {
+	lt := errortranslator.NewLocaleTranslator(language.English)
+	lt.Locale(language.English).SetFallbackTranslation(validate.ErrRequired, "The {field} is required")
+	lt.Locale(language.Dutch).SetFallbackTranslation(validate.ErrRequired, "{field} is verplicht")
+	return lt
+}
+
+// serve handles a request with the middleware and a handler that writes the error map
+func serve(c *C, accept string, acceptLanguage string) *httptest.ResponseRecorder {
+	handler := httperror.Middleware(newLocaleTranslator())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Assert(httperror.WriteValidationError(w, r, errorMap), IsNil)
+	}))
+
+	r := httptest.NewRequest(http.MethodPost, "/users", nil)
+	r.Header.Set("Accept", accept)
+	r.Header.Set("Accept-Language", acceptLanguage)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	return w
+}
+
+func (s *HTTPErrorSuite) TestWriteJSON(c *C) {
+	w := serve(c, "application/json", "nl-BE, en;q=0.5")
+
+	c.Assert(w.Code, Equals, http.StatusUnprocessableEntity)
+	c.Assert(w.Header().Get("Content-Type"), Equals, "application/json")
+	c.Assert(w.Header().Get("Content-Language"), Equals, "nl")
+	c.Assert(w.Header().Get("Vary"), Equals, "Accept, Accept-Language")
+	c.Assert(w.Body.String(), Equals, `{"email":"email is verplicht","name":"name is verplicht"}`)
+}
+
+func (s *HTTPErrorSuite) TestWriteUntranslated(c *C) {
+	ft := errortranslator.New().SetFallbackTranslation(validate.ErrRequired, "required")
+	errorMap := validate.ErrorMap{
+		"name": validate.Errors{validate.ErrRequired, validate.ErrMin},
+		"age":  validate.Errors{validate.ErrMin, validate.ErrMax},
+	}
+
+	tests := []struct {
+		Accept   string
+		Expected string
+	}{
+		{Accept: "application/json", Expected: `{"age":"min, max","name":"required, min"}`},
+		{Accept: "text/plain", Expected: "age: min, max\nname: required, min\n"},
+		{Accept: "application/problem+json", Expected: `{"title":"Unprocessable Entity","status":422,"errors":[` +
+			`{"pointer":"/age","code":"min"},{"pointer":"/age","code":"max"},` +
+			`{"pointer":"/name","code":"required","detail":"required"},{"pointer":"/name","code":"min"}]}`},
+	}
+
+	for _, test := range tests {
+		r := httptest.NewRequest(http.MethodPost, "/users", nil)
+		r.Header.Set("Accept", test.Accept)
+		r = r.WithContext(httperror.WithTranslator(r.Context(), ft, language.Und))
+
+		w := httptest.NewRecorder()
+		c.Assert(httperror.WriteValidationError(w, r, errorMap), IsNil)
+		c.Assert(w.Body.String(), Equals, test.Expected, Commentf(test.Accept))
+	}
+}
+
+func (s *HTTPErrorSuite) TestWriteDeduplicated(c *C) {
+	ft := errortranslator.New().
+		SetFallbackTranslation(validate.ErrRequired, "invalid").
+		SetFallbackTranslation(validate.ErrMin, "invalid").
+		WithOptions(errortranslator.Options{Dedupe: true, Joiner: errortranslator.SeparatorJoiner("; ")})
+
+	r := httptest.NewRequest(http.MethodPost, "/users", nil)
+	r = r.WithContext(httperror.WithTranslator(r.Context(), ft, language.Und))
+
+	w := httptest.NewRecorder()
+	c.Assert(httperror.WriteValidationError(w, r, validate.ErrorMap{
+		"name": validate.Errors{validate.ErrRequired, validate.ErrMin, validate.ErrMax},
+	}), IsNil)
+
+	//the error left out by Dedupe is not written as untranslated
+	c.Assert(w.Body.String(), Equals, `{"name":"invalid; max"}`)
+}
+
+func (s *HTTPErrorSuite) TestWriteProblem(c *C) {
+	w := serve(c, "application/problem+json", "en")
+
+	c.Assert(w.Code, Equals, http.StatusUnprocessableEntity)
+	c.Assert(w.Header().Get("Content-Type"), Equals, "application/problem+json")
+	c.Assert(w.Header().Get("Content-Language"), Equals, "en")
+	c.Assert(w.Body.String(), Equals, `{"title":"Unprocessable Entity","status":422,"errors":[`+
+		`{"pointer":"/email","code":"required","detail":"The email is required"},`+
+		`{"pointer":"/name","code":"required","detail":"The name is required"}]}`)
+}
+
+func (s *HTTPErrorSuite) TestWriteText(c *C) {
+	w := serve(c, "text/plain", "fr")
+
+	c.Assert(w.Code, Equals, http.StatusUnprocessableEntity)
+	c.Assert(w.Header().Get("Content-Type"), Equals, "text/plain; charset=utf-8")
+	c.Assert(w.Header().Get("Content-Language"), Equals, "en")
+	c.Assert(w.Body.String(), Equals, "email: The email is required\nname: The name is required\n")
+}
+
+func (s *HTTPErrorSuite) TestWithTranslator(c *C) {
+	ft := errortranslator.New().SetFallbackTranslation(validate.ErrRequired, "required")
+
+	r := httptest.NewRequest(http.MethodPost, "/users", nil)
+	r = r.WithContext(httperror.WithTranslator(context.Background(), ft, language.Und))
+
+	translator, locale, ok := httperror.FromContext(r.Context())
+	c.Assert(ok, Equals, true)
+	c.Assert(locale, Equals, language.Und)
+	c.Assert(translator, NotNil)
+
+	w := httptest.NewRecorder()
+	c.Assert(httperror.WriteValidationError(w, r, validate.ErrorMap{"name": validate.Errors{validate.ErrRequired}}), IsNil)
+	c.Assert(w.Header().Get("Content-Language"), Equals, "")
+	c.Assert(w.Body.String(), Equals, `{"name":"required"}`)
+}
+
+func (s *HTTPErrorSuite) TestNoTranslator(c *C) {
+	w := httptest.NewRecorder()
+	err := httperror.WriteValidationError(w, httptest.NewRequest(http.MethodGet, "/", nil), errorMap)
+
+	c.Assert(err, Equals, httperror.ErrNoTranslator)
+	c.Assert(w.Body.Len(), Equals, 0)
+}
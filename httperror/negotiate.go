@@ -0,0 +1,82 @@
+package httperror
+
+import (
+	"strconv"
+	"strings"
+)
+
+// offers are the media types that can be written in order of preference
+var offers = []string{ContentTypeJSON, ContentTypeProblem, ContentTypeText}
+
+// Negotiate returns the media type to write for the Accept header value. The media type with the highest quality wins,
+// on equal quality JSON is preferred over problem details and plain text. JSON is returned when the header is empty or
+// none of the media types is acceptable.
+func Negotiate(accept string) string {
+	if strings.TrimSpace(accept) == "" {
+		return ContentTypeJSON
+	}
+
+	ranges := parseAccept(accept)
+
+	best, bestQuality := ContentTypeJSON, 0.0
+	for _, offer := range offers {
+		if q := quality(ranges, offer); q > bestQuality {
+			best, bestQuality = offer, q
+		}
+	}
+	return best
+}
+
+// mediaRange is a media range of a Accept header
+type mediaRange struct {
+	typ, subtype string
+	quality      float64
+}
+
+func parseAccept(accept string) []mediaRange {
+	var ranges []mediaRange
+	for _, part := range strings.Split(accept, ",") {
+		params := strings.Split(part, ";")
+		typ, subtype, ok := strings.Cut(strings.ToLower(strings.TrimSpace(params[0])), "/")
+		if !ok {
+			continue
+		}
+
+		r := mediaRange{typ: typ, subtype: subtype, quality: 1}
+		for _, param := range params[1:] {
+			key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if strings.ToLower(key) != "q" {
+				continue
+			}
+
+			if q, err := strconv.ParseFloat(value, 64); err == nil {
+				r.quality = q
+			}
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges
+}
+
+// quality returns the quality of the most specific media range that matches the media type
+func quality(ranges []mediaRange, mediaType string) float64 {
+	typ, subtype, _ := strings.Cut(mediaType, "/")
+
+	q, specificity := 0.0, -1
+	for _, r := range ranges {
+		s := -1
+		switch {
+		case r.typ == typ && r.subtype == subtype:
+			s = 2
+		case r.typ == typ && r.subtype == "*":
+			s = 1
+		case r.typ == "*" && r.subtype == "*":
+			s = 0
+		}
+
+		if s > specificity {
+			q, specificity = r.quality, s
+		}
+	}
+	return q
+}
@@ -0,0 +1,34 @@
+package httperror_test
+
+import (
+	"github.com/mbict/go-errortranslator/httperror"
+	. "gopkg.in/check.v1"
+)
+
+type NegotiateSuite struct{}
+
+var _ = Suite(&NegotiateSuite{})
+
+func (s *NegotiateSuite) TestNegotiate(c *C) {
+	tests := []struct {
+		Accept   string
+		Expected string
+	}{
+		{Accept: "", Expected: httperror.ContentTypeJSON},
+		{Accept: "*/*", Expected: httperror.ContentTypeJSON},
+		{Accept: "application/json", Expected: httperror.ContentTypeJSON},
+		{Accept: "application/problem+json", Expected: httperror.ContentTypeProblem},
+		{Accept: "text/plain", Expected: httperror.ContentTypeText},
+		{Accept: "text/*", Expected: httperror.ContentTypeText},
+		{Accept: "text/html", Expected: httperror.ContentTypeJSON},
+		{Accept: "application/json;q=0.5, application/problem+json", Expected: httperror.ContentTypeProblem},
+		{Accept: "text/plain, */*;q=0.1", Expected: httperror.ContentTypeText},
+		{Accept: "application/*;q=0.2, text/plain;q=0.3", Expected: httperror.ContentTypeText},
+		{Accept: "application/*, application/json;q=0", Expected: httperror.ContentTypeProblem},
+		{Accept: "Application/Problem+JSON", Expected: httperror.ContentTypeProblem},
+	}
+
+	for _, test := range tests {
+		c.Assert(httperror.Negotiate(test.Accept), Equals, test.Expected, Commentf(test.Accept))
+	}
+}
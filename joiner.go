@@ -0,0 +1,154 @@
+package errortranslator
+
+import (
+	"html"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// Joiner joins the translated messages of a field into a single message.
+// The locale is the language the messages are translated in, language.Und when the language is unknown.
+type Joiner interface {
+	Join(locale language.Tag, messages []string) string
+}
+
+// JoinerFunc is a function that can be used as Joiner.
+type JoinerFunc func(locale language.Tag, messages []string) string
+
+// Join calls the function
+func (f JoinerFunc) Join(locale language.Tag, messages []string) string {
+	return f(locale, messages)
+}
+
+// DefaultJoiner is used when no joiner is configured, the messages are separated by a comma.
+var DefaultJoiner Joiner = SeparatorJoiner(", ")
+
+// SeparatorJoiner returns a Joiner that separates the messages with the separator, e.g. "; " or "\n".
+func SeparatorJoiner(separator string) Joiner {
+	return JoinerFunc(func(_ language.Tag, messages []string) string {
+		return strings.Join(messages, separator)
+	})
+}
+
+// ListJoiner returns a Joiner that formats the messages as a list in the language of the messages with the CLDR list
+// patterns, "a, b, and c" in English and "a, b en c" in Dutch. The patterns of a small set of languages are included,
+// see listPatterns. Other languages separate all the messages with a comma, the English pattern is used when the
+// language is undefined.
+func ListJoiner() Joiner {
+	return JoinerFunc(func(locale language.Tag, messages []string) string {
+		return lookupListPattern(locale).format(messages)
+	})
+}
+
+// HTMLListJoiner returns a Joiner that formats the messages as a HTML unordered list, the messages are HTML escaped.
+func HTMLListJoiner() Joiner {
+	return JoinerFunc(func(_ language.Tag, messages []string) string {
+		var b strings.Builder
+		b.WriteString("<ul>")
+		for _, message := range messages {
+			b.WriteString("<li>")
+			b.WriteString(html.EscapeString(message))
+			b.WriteString("</li>")
+		}
+		b.WriteString("</ul>")
+		return b.String()
+	})
+}
+
+// listPattern is a CLDR list pattern (standard style). The parts are the text between the elements: the middle is
+// used between all elements but the last two, the end between the last two and the two between a list of two.
+type listPattern struct {
+	two, middle, end string
+}
+
+// listPatterns holds the standard list patterns from CLDR for the supported languages, a small subset of the languages
+// in CLDR.
+var listPatterns = map[language.Tag]listPattern{
+	language.English:             {two: " and ", middle: ", ", end: ", and "},
+	language.MustParse("en-001"): {two: " and ", middle: ", ", end: " and "},
+	language.Dutch:               {two: " en ", middle: ", ", end: " en "},
+	language.German:              {two: " und ", middle: ", ", end: " und "},
+	language.French:              {two: " et ", middle: ", ", end: " et "},
+	language.Spanish:             {two: " y ", middle: ", ", end: " y "},
+	language.Italian:             {two: " e ", middle: ", ", end: " e "},
+	language.Portuguese:          {two: " e ", middle: ", ", end: " e "},
+	language.Danish:              {two: " og ", middle: ", ", end: " og "},
+	language.Norwegian:           {two: " og ", middle: ", ", end: " og "},
+	language.Swedish:             {two: " och ", middle: ", ", end: " och "},
+	language.MustParse("nb"):     {two: " og ", middle: ", ", end: " og "},
+	language.MustParse("fi"):     {two: " ja ", middle: ", ", end: " ja "},
+	language.MustParse("pl"):     {two: " i ", middle: ", ", end: " i "},
+}
+
+// neutralListPattern is used for the languages without a list pattern, it does not contain words of any language.
+var neutralListPattern = listPattern{two: ", ", middle: ", ", end: ", "}
+
+// lookupListPattern returns the list pattern of the language or its parents. The English pattern is used for a
+// undefined language, the neutral pattern for languages without a list pattern.
+func lookupListPattern(locale language.Tag) listPattern {
+	if locale == language.Und {
+		return listPatterns[language.English]
+	}
+
+	for tag := locale; tag != language.Und; tag = tag.Parent() {
+		if pattern, ok := listPatterns[tag]; ok {
+			return pattern
+		}
+	}
+	return neutralListPattern
+}
+
+func (p listPattern) format(messages []string) string {
+	switch len(messages) {
+	case 0:
+		return ""
+	case 1:
+		return messages[0]
+	case 2:
+		return messages[0] + p.two + messages[1]
+	}
+
+	last := len(messages) - 1
+	return strings.Join(messages[:last], p.middle) + p.end + messages[last]
+}
+
+// joiner returns the joiner of the options, DefaultJoiner when no joiner is set
+func (opts Options) joiner() Joiner {
+	if opts.Joiner == nil {
+		return DefaultJoiner
+	}
+	return opts.Joiner
+}
+
+// joinTranslations joins the messages of the translations into a single message, empty messages are skipped.
+func joinTranslations(joiner Joiner, locale language.Tag, translations []Translation) (string, bool) {
+	messages := make([]string, 0, len(translations))
+	for _, translation := range translations {
+		if translation.Message != "" {
+			messages = append(messages, translation.Message)
+		}
+	}
+
+	if len(messages) == 0 {
+		return "", false
+	}
+
+	if joiner == nil {
+		joiner = DefaultJoiner
+	}
+	return joiner.Join(locale, messages), true
+}
+
+// joinTranslationMap joins the messages of the translations per field, fields with only empty messages are left out.
+func joinTranslationMap(joiner Joiner, locale language.Tag, translations map[string][]Translation, ok bool) (map[string]string, bool) {
+	result := make(map[string]string, len(translations))
+	for field, t := range translations {
+		message, translated := joinTranslations(joiner, locale, t)
+		ok = ok && translated
+		if translated {
+			result[field] = message
+		}
+	}
+	return result, ok
+}
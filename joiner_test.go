@@ -0,0 +1,124 @@
+package errortranslator_test
+
+import (
+	errortranslator "github.com/mbict/go-errortranslator"
+	validate "github.com/mbict/go-validate"
+	"golang.org/x/text/language"
+	. "gopkg.in/check.v1"
+)
+
+type JoinerSuite struct{}
+
+var _ = Suite(&JoinerSuite{})
+
+func (s *JoinerSuite) TestSeparatorJoiner(c *C) {
+	joiner := errortranslator.SeparatorJoiner("; ")
+
+	c.Assert(joiner.Join(language.Und, []string{"a"}), Equals, "a")
+	c.Assert(joiner.Join(language.Und, []string{"a", "b", "c"}), Equals, "a; b; c")
+	c.Assert(errortranslator.DefaultJoiner.Join(language.Und, []string{"a", "b"}), Equals, "a, b")
+}
+
+func (s *JoinerSuite) TestListJoiner(c *C) {
+	joiner := errortranslator.ListJoiner()
+
+	tests := []struct {
+		Locale   string
+		Messages []string
+		Expected string
+	}{
+		{Locale: "en", Messages: []string{"a"}, Expected: "a"},
+		{Locale: "en", Messages: []string{"a", "b"}, Expected: "a and b"},
+		{Locale: "en", Messages: []string{"a", "b", "c"}, Expected: "a, b, and c"},
+		{Locale: "en-GB", Messages: []string{"a", "b", "c"}, Expected: "a, b and c"},
+		{Locale: "nl", Messages: []string{"a", "b", "c"}, Expected: "a, b en c"},
+		{Locale: "nl-BE", Messages: []string{"a", "b"}, Expected: "a en b"},
+		{Locale: "de", Messages: []string{"a", "b", "c", "d"}, Expected: "a, b, c und d"},
+		{Locale: "und", Messages: []string{"a", "b", "c"}, Expected: "a, b, and c"},
+		{Locale: "ja", Messages: []string{"a", "b"}, Expected: "a, b"},
+		{Locale: "ru", Messages: []string{"а", "б", "в"}, Expected: "а, б, в"},
+	}
+
+	for _, test := range tests {
+		result := joiner.Join(language.MustParse(test.Locale), test.Messages)
+
+		c.Assert(result, Equals, test.Expected, Commentf(test.Locale))
+	}
+}
+
+func (s *JoinerSuite) TestHTMLListJoiner(c *C) {
+	result := errortranslator.HTMLListJoiner().Join(language.Und, []string{"a < b", "c & d"})
+
+	c.Assert(result, Equals, "<ul><li>a &lt; b</li><li>c &amp; d</li></ul>")
+}
+
+func (s *JoinerSuite) TestJoinerFunc(c *C) {
+	et := errortranslator.ErrorTranslator{
+		validate.ErrRequired: "required translate",
+		validate.ErrMin:      "min translate",
+	}
+
+	joiner := errortranslator.JoinerFunc(func(_ language.Tag, messages []string) string {
+		return messages[len(messages)-1]
+	})
+
+	result, ok := et.TranslateJoin(validate.Errors{validate.ErrRequired, validate.ErrMin}, joiner)
+
+	c.Assert(ok, Equals, true)
+	c.Assert(result, Equals, "min translate")
+
+	result, ok = et.TranslateJoin(validate.Errors{validate.ErrMax}, joiner)
+
+	c.Assert(ok, Equals, false)
+	c.Assert(result, Equals, "")
+}
+
+func (s *JoinerSuite) TestTranslatorJoiner(c *C) {
+	t := errortranslator.New().
+		AddTranslation("A", validate.ErrRequired, "required translate").
+		AddTranslation("A", validate.ErrMin, "min translate").
+		WithOptions(errortranslator.Options{Joiner: errortranslator.SeparatorJoiner("\n")})
+
+	result, ok := t.Translate(validate.ErrorMap{
+		"A": validate.Errors{validate.ErrRequired, validate.ErrMin},
+	})
+
+	c.Assert(ok, Equals, true)
+	c.Assert(result, DeepEquals, map[string]string{"A": "required translate\nmin translate"})
+}
+
+func (s *JoinerSuite) TestLocaleTranslatorJoiner(c *C) {
+	lt := errortranslator.NewLocaleTranslator(language.English)
+	lt.AddLocale(language.English, errortranslator.New().
+		AddTranslation("A", validate.ErrRequired, "required").
+		AddTranslation("A", validate.ErrMin, "too short").
+		AddTranslation("A", validate.ErrMax, "too long"))
+	lt.AddLocale(language.Dutch, errortranslator.New().
+		AddTranslation("A", validate.ErrRequired, "verplicht").
+		AddTranslation("A", validate.ErrMin, "te kort"))
+	lt.SetOptions(errortranslator.Options{Joiner: errortranslator.ListJoiner()})
+
+	errorMap := validate.ErrorMap{
+		"A": validate.Errors{validate.ErrRequired, validate.ErrMin, validate.ErrMax},
+	}
+
+	result, ok := lt.Translate(language.English, errorMap)
+	c.Assert(ok, Equals, true)
+	c.Assert(result, DeepEquals, map[string]string{"A": "required, too short, and too long"})
+
+	result, ok = lt.Translate(language.Dutch, errorMap)
+	c.Assert(ok, Equals, true)
+	c.Assert(result, DeepEquals, map[string]string{"A": "verplicht, te kort en too long"})
+}
+
+func (s *JoinerSuite) TestJoinerAccessor(c *C) {
+	joiner := errortranslator.SeparatorJoiner("; ")
+	messages := []string{"a", "b"}
+
+	c.Assert(errortranslator.New().WithOptions(errortranslator.Options{}).Joiner().Join(language.English, messages), Equals, "a, b")
+	c.Assert(errortranslator.New().WithOptions(errortranslator.Options{Joiner: joiner}).Joiner().Join(language.English, messages), Equals, "a; b")
+	c.Assert(errortranslator.NewSyncTranslator(errortranslator.New()).Joiner().Join(language.English, messages), Equals, "a, b")
+
+	lt := errortranslator.NewLocaleTranslator(language.English).SetOptions(errortranslator.Options{Joiner: joiner})
+	c.Assert(lt.ForLocale(language.Dutch).Joiner().Join(language.Dutch, messages), Equals, "a; b")
+}
@@ -0,0 +1,51 @@
+package errortranslator
+
+import "reflect"
+
+// LabelTag is the struct tag that holds the display name of a field
+const LabelTag = "label"
+
+// Labels maps field names to display names, the {label} placeholder in a message is replaced with the label of the
+// field. A single fallback translation like "{label} is required" results in "Email address is required" for the
+// field `Email`. Field names can be patterns (see Match), fields without a label use the field name as label.
+type Labels map[string]string
+
+// Label returns the label of the field, a exact match or the label of the most specific matching pattern.
+func (l Labels) Label(field string) (string, bool) {
+	return l.label(field, newPatternIndex(l))
+}
+
+// label works the same as Label with the pattern index of the labels
+func (l Labels) label(field string, patterns patternIndex) (string, bool) {
+	if label, ok := l[field]; ok {
+		return label, true
+	}
+
+	if keys := patterns.match(field); len(keys) > 0 {
+		return l[keys[0]], true
+	}
+	return "", false
+}
+
+// LabelsFromStruct returns the labels of the `label` tags of a struct:
+//
+//	type User struct {
+//		Email string `label:"Email address"`
+//	}
+//
+// The field keys are the paths of the fields like FromStruct uses them, fields without tag have no label.
+// v is a struct, a pointer to a struct or a reflect.Type of one of these.
+func LabelsFromStruct(v interface{}) Labels {
+	t, ok := v.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(v)
+	}
+
+	labels := Labels{}
+	walkStructFields(indirectType(t), "", make(map[reflect.Type]bool), func(path string, f reflect.StructField) {
+		if label, ok := f.Tag.Lookup(LabelTag); ok {
+			labels[path] = label
+		}
+	})
+	return labels
+}
@@ -0,0 +1,115 @@
+package errortranslator_test
+
+import (
+	errortranslator "github.com/mbict/go-errortranslator"
+	validate "github.com/mbict/go-validate"
+	"golang.org/x/text/language"
+	. "gopkg.in/check.v1"
+)
+
+type LabelsSuite struct{}
+
+var _ = Suite(&LabelsSuite{})
+
+func (s *LabelsSuite) TestLabel(c *C) {
+	labels := errortranslator.Labels{
+		"Email":         "Email address",
+		"Lines.*.Price": "Price",
+		"Lines.**":      "Order line",
+	}
+
+	tests := []struct {
+		Field    string
+		Expected string
+		Ok       bool
+	}{
+		{Field: "Email", Expected: "Email address", Ok: true},
+		{Field: "Lines.1.Price", Expected: "Price", Ok: true},
+		{Field: "Lines[2].Price", Expected: "Price", Ok: true},
+		{Field: "Lines.1.Quantity", Expected: "Order line", Ok: true},
+		{Field: "Name", Expected: "", Ok: false},
+	}
+
+	for _, test := range tests {
+		label, ok := labels.Label(test.Field)
+
+		c.Assert(ok, Equals, test.Ok, Commentf(test.Field))
+		c.Assert(label, Equals, test.Expected, Commentf(test.Field))
+	}
+
+	label, ok := errortranslator.Labels(nil).Label("Email")
+	c.Assert(label, Equals, "")
+	c.Assert(ok, Equals, false)
+}
+
+func (s *LabelsSuite) TestLabelsFromStruct(c *C) {
+	type line struct {
+		Price int `label:"Price"`
+	}
+
+	labels := errortranslator.LabelsFromStruct(struct {
+		Email string `label:"Email address"`
+		Name  string
+		Lines []line `label:"Order lines"`
+	}{})
+
+	c.Assert(labels, DeepEquals, errortranslator.Labels{
+		"Email":         "Email address",
+		"Lines":         "Order lines",
+		"Lines.*.Price": "Price",
+	})
+}
+
+func (s *LabelsSuite) TestTranslateWithLabels(c *C) {
+	t := errortranslator.New().
+		SetFallbackTranslation(validate.ErrRequired, "{label} is required").
+		SetFallbackDefaultTranslation("{label} ({field}) is invalid").
+		WithOptions(errortranslator.Options{
+			Labels: errortranslator.Labels{"Email": "Email address", "Lines.*.Price": "Price"},
+		})
+
+	result, ok := t.Translate(validate.ErrorMap{
+		"Email":         validate.Errors{validate.ErrRequired},
+		"Lines.0.Price": validate.Errors{validate.ErrMin},
+		"Name":          validate.Errors{validate.ErrRequired},
+	})
+
+	c.Assert(ok, Equals, true)
+	c.Assert(result, DeepEquals, map[string]string{
+		"Email":         "Email address is required",
+		"Lines.0.Price": "Price (Lines.0.Price) is invalid",
+		"Name":          "Name is required",
+	})
+}
+
+func (s *LabelsSuite) TestLocaleLabels(c *C) {
+	lt := errortranslator.NewLocaleTranslator(language.English)
+	lt.Locale(language.English).SetFallbackTranslation(validate.ErrRequired, "{label} is required")
+	lt.Locale(language.Dutch).SetFallbackTranslation(validate.ErrRequired, "{label} is verplicht")
+	lt.Locale(language.MustParse("nl-BE"))
+	lt.SetLabels(language.English, errortranslator.Labels{"Email": "Email address", "Phone": "Phone number"})
+	lt.SetLabels(language.Dutch, errortranslator.Labels{"Email": "E-mailadres"})
+	lt.SetOptions(errortranslator.Options{Labels: errortranslator.Labels{"Name": "Full name"}})
+
+	errorMap := validate.ErrorMap{
+		"Email": validate.Errors{validate.ErrRequired},
+		"Phone": validate.Errors{validate.ErrRequired},
+		"Name":  validate.Errors{validate.ErrRequired},
+	}
+
+	result, ok := lt.Translate(language.MustParse("nl-BE"), errorMap)
+	c.Assert(ok, Equals, true)
+	c.Assert(result, DeepEquals, map[string]string{
+		"Email": "E-mailadres is verplicht",
+		"Phone": "Phone number is verplicht",
+		"Name":  "Full name is verplicht",
+	})
+
+	result, ok = lt.Translate(language.English, errorMap)
+	c.Assert(ok, Equals, true)
+	c.Assert(result, DeepEquals, map[string]string{
+		"Email": "Email address is required",
+		"Phone": "Phone number is required",
+		"Name":  "Full name is required",
+	})
+}
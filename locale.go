@@ -0,0 +1,312 @@
+package errortranslator
+
+import (
+	validate "github.com/mbict/go-validate"
+	"golang.org/x/text/language"
+)
+
+// LocaleTranslator holds a FieldErrorTranslator per language.
+// The best matching language is selected with BCP 47 matching, when a translation is missing for the selected
+// language the parent languages are tried (nl-BE -> nl) and finally the default language.
+// Translating is safe for concurrent use as long as no languages, translations or options are added or changed.
+type LocaleTranslator struct {
+	defaultLocale language.Tag
+	translators   map[language.Tag]FieldErrorTranslator
+	patterns      map[language.Tag]*patternCache
+	tags          []language.Tag
+	matcher       language.Matcher
+	options       Options
+	labels        map[language.Tag]Labels
+	labelPatterns map[language.Tag]patternIndex
+}
+
+// NewLocaleTranslator creates a new locale translator, the default locale is used when no language matches and
+// as the last fallback for missing translations.
+func NewLocaleTranslator(defaultLocale language.Tag) *LocaleTranslator {
+	return &LocaleTranslator{
+		defaultLocale: defaultLocale,
+		translators:   make(map[language.Tag]FieldErrorTranslator),
+		patterns:      make(map[language.Tag]*patternCache),
+	}
+}
+
+// AddLocale adds the translations for a language. If translations for the language are already present they will be
+// replaced. The function returns the LocaleTranslator for chaining.
+func (lt *LocaleTranslator) AddLocale(locale language.Tag, ft FieldErrorTranslator) *LocaleTranslator {
+	if _, ok := lt.translators[locale]; !ok {
+		lt.tags = append(lt.tags, locale)
+		lt.matcher = lt.newMatcher()
+	}
+	lt.translators[locale] = ft
+	lt.patterns[locale] = &patternCache{}
+	return lt
+}
+
+// newMatcher creates the matcher for the registered languages. The matcher is created when a language is added so
+// translating does not modify the LocaleTranslator.
+func (lt *LocaleTranslator) newMatcher() language.Matcher {
+	//the first tag is the one the matcher returns when nothing matches
+	tags := []language.Tag{lt.defaultLocale}
+	for _, tag := range lt.tags {
+		if tag != lt.defaultLocale {
+			tags = append(tags, tag)
+		}
+	}
+	return language.NewMatcher(tags)
+}
+
+// SetOptions sets the options used to translate, see Options. The function returns the LocaleTranslator for chaining.
+func (lt *LocaleTranslator) SetOptions(opts Options) *LocaleTranslator {
+	lt.options = opts
+	return lt
+}
+
+// Locale returns the translations registered for the exact language, when not present a empty FieldErrorTranslator
+// is registered for the language. Useful to add translations: localetranslator.Locale(language.Dutch).AddTranslation(...)
+// The pattern keys of a language are indexed when it is used to translate, change the translations of a language that
+// is already used through Locale or AddLocale so the index is built again.
+func (lt *LocaleTranslator) Locale(locale language.Tag) FieldErrorTranslator {
+	ft, ok := lt.translators[locale]
+	if !ok {
+		ft = New()
+		lt.AddLocale(locale, ft)
+	}
+
+	//the translations are returned to be changed, the pattern keys are indexed again when translating
+	lt.patterns[locale].reset()
+	return ft
+}
+
+// Locales returns the registered languages in order of registration.
+func (lt *LocaleTranslator) Locales() []language.Tag {
+	return append([]language.Tag(nil), lt.tags...)
+}
+
+// DefaultLocale returns the default language
+func (lt *LocaleTranslator) DefaultLocale() language.Tag {
+	return lt.defaultLocale
+}
+
+// Match returns the registered language that matches the desired languages best, the desired languages are in order
+// of preference. When none of the languages match the default language is returned.
+func (lt *LocaleTranslator) Match(desired ...language.Tag) language.Tag {
+	if len(lt.tags) == 0 {
+		return lt.defaultLocale
+	}
+
+	_, index, confidence := lt.matcher.Match(desired...)
+	if confidence == language.No || index == 0 {
+		return lt.defaultLocale
+	}
+
+	//index 0 is the default locale, the registered tags follow without the default locale
+	for _, tag := range lt.tags {
+		if tag == lt.defaultLocale {
+			continue
+		}
+		if index--; index == 0 {
+			return tag
+		}
+	}
+	return lt.defaultLocale
+}
+
+// MatchAcceptLanguage returns the registered language that matches the Accept-Language header value best.
+// A single language tag like `nl-BE` is a valid value too.
+func (lt *LocaleTranslator) MatchAcceptLanguage(accept string) language.Tag {
+	desired, _, err := language.ParseAcceptLanguage(accept)
+	if err != nil {
+		return lt.defaultLocale
+	}
+	return lt.Match(desired...)
+}
+
+// Translate translates the error map into the language that matches the locale best.
+// Missing translations are looked up in the parent languages and the default language, the field translations of
+// all these languages are tried before their fallback translations (see SetFallbackTranslation).
+// The fallback translators are only used when no language has a translation for the error.
+func (lt *LocaleTranslator) Translate(locale language.Tag, errorMap validate.ErrorMap, fallback ...ErrorTranslator) (map[string]string, bool) {
+	return lt.translate(lt.Match(locale), errorMap, false, fallback)
+}
+
+// TranslateFirst works the same as Translate but will stop after the first positive match is found per field entry.
+func (lt *LocaleTranslator) TranslateFirst(locale language.Tag, errorMap validate.ErrorMap, fallback ...ErrorTranslator) (map[string]string, bool) {
+	return lt.translate(lt.Match(locale), errorMap, true, fallback)
+}
+
+// TranslateAcceptLanguage works the same as Translate but selects the language with a Accept-Language header value.
+func (lt *LocaleTranslator) TranslateAcceptLanguage(accept string, errorMap validate.ErrorMap, fallback ...ErrorTranslator) (map[string]string, bool) {
+	return lt.translate(lt.MatchAcceptLanguage(accept), errorMap, false, fallback)
+}
+
+// TranslateFirstAcceptLanguage works the same as TranslateFirst but selects the language with a Accept-Language
+// header value.
+func (lt *LocaleTranslator) TranslateFirstAcceptLanguage(accept string, errorMap validate.ErrorMap, fallback ...ErrorTranslator) (map[string]string, bool) {
+	return lt.translate(lt.MatchAcceptLanguage(accept), errorMap, true, fallback)
+}
+
+// TranslateDetails works the same as Translate but returns a Translation for every translated error of a field instead
+// of a single joined message.
+func (lt *LocaleTranslator) TranslateDetails(locale language.Tag, errorMap validate.ErrorMap, fallback ...ErrorTranslator) (map[string][]Translation, bool) {
+	return lt.translateErrorMap(lt.Match(locale), errorMap, false, fallback)
+}
+
+// TranslateFirstDetails works the same as TranslateDetails but will stop after the first positive match is found per
+// field entry.
+func (lt *LocaleTranslator) TranslateFirstDetails(locale language.Tag, errorMap validate.ErrorMap, fallback ...ErrorTranslator) (map[string][]Translation, bool) {
+	return lt.translateErrorMap(lt.Match(locale), errorMap, true, fallback)
+}
+
+// translate translates the error map and joins the messages of a field with the joiner of the options.
+func (lt *LocaleTranslator) translate(locale language.Tag, errorMap validate.ErrorMap, firstOnly bool, fallback []ErrorTranslator) (map[string]string, bool) {
+	translations, ok := lt.translateErrorMap(locale, errorMap, firstOnly, fallback)
+	return joinTranslationMap(lt.options.Joiner, locale, translations, ok)
+}
+
+func (lt *LocaleTranslator) translateErrorMap(locale language.Tag, errorMap validate.ErrorMap, firstOnly bool, fallback []ErrorTranslator) (map[string][]Translation, bool) {
+	chain := lt.chain(locale)
+	labels := newPatternIndex(lt.options.Labels)
+	return translateErrorMap(errorMap, firstOnly, lt.options, locale, func(field string, err error) (Translation, bool) {
+		//messages are rendered with the plural rules of the language that provided the translation
+		contexts := make([]messageContext, len(chain))
+		for i, l := range chain {
+			contexts[i] = messageContext{field: field, label: lt.label(chain[i:], field, labels), locale: l.locale}
+		}
+
+		//the field translations of all the languages have precedence over the fallback translations, a regional
+		//language with only fallback translations does not hide the field translations of the parent language
+		for i, l := range chain {
+			if translation, ok := l.translator.translateFieldError(contexts[i], err, nil, lt.options, l.patterns); ok {
+				return translation, true
+			}
+		}
+
+		for i, l := range chain {
+			defaults := l.translator.withFieldDefaults(nil)
+			if len(defaults) == 0 {
+				continue
+			}
+
+			if translation, _, ok := defaults[0].translate(contexts[i], err, nil); ok {
+				translation.UsedFallback = true
+				return translation, true
+			}
+		}
+
+		if len(fallback) == 0 {
+			return Translation{}, false
+		}
+
+		ctx := messageContext{field: field, label: lt.label(chain, field, labels), locale: locale}
+		translation, _, ok := fallback[0].translate(ctx, err, fallback[1:])
+		translation.UsedFallback = true
+		return translation, ok
+	})
+}
+
+// SetLabels sets the display names of the fields for a language, used for the {label} placeholder (see Labels).
+// Missing labels are looked up in the same languages as missing translations, the labels of the options are used last.
+// The LocaleTranslator uses a copy of the labels. The function returns the LocaleTranslator for chaining.
+func (lt *LocaleTranslator) SetLabels(locale language.Tag, labels Labels) *LocaleTranslator {
+	if lt.labels == nil {
+		lt.labels = make(map[language.Tag]Labels)
+		lt.labelPatterns = make(map[language.Tag]patternIndex)
+	}
+
+	copied := make(Labels, len(labels))
+	for field, label := range labels {
+		copied[field] = label
+	}
+	lt.labels[locale] = copied
+	lt.labelPatterns[locale] = newPatternIndex(copied)
+	return lt
+}
+
+// label returns the label of the field from the first language in the chain that has one, optionLabels is the pattern
+// index of the labels of the options.
+func (lt *LocaleTranslator) label(chain []localeTranslations, field string, optionLabels patternIndex) string {
+	for _, l := range chain {
+		if label, ok := lt.labels[l.locale].label(field, lt.labelPatterns[l.locale]); ok {
+			return label
+		}
+	}
+
+	label, _ := lt.options.Labels.label(field, optionLabels)
+	return label
+}
+
+// LocalizedTranslator translates into a single language of a LocaleTranslator.
+type LocalizedTranslator struct {
+	translator *LocaleTranslator
+	locale     language.Tag
+}
+
+// ForLocale returns a translator for the registered language that matches the locale best (see Match).
+// The returned translator implements the same translate functions as a FieldErrorTranslator.
+func (lt *LocaleTranslator) ForLocale(locale language.Tag) *LocalizedTranslator {
+	return &LocalizedTranslator{
+		translator: lt,
+		locale:     lt.Match(locale),
+	}
+}
+
+// Locale returns the language the translator translates into.
+func (t *LocalizedTranslator) Locale() language.Tag {
+	return t.locale
+}
+
+// Joiner returns the joiner of the options of the LocaleTranslator, DefaultJoiner when no joiner is set.
+func (t *LocalizedTranslator) Joiner() Joiner {
+	return t.translator.options.joiner()
+}
+
+// Translate works the same as LocaleTranslator.Translate for the language of the translator.
+func (t *LocalizedTranslator) Translate(errorMap validate.ErrorMap, fallback ...ErrorTranslator) (map[string]string, bool) {
+	return t.translator.translate(t.locale, errorMap, false, fallback)
+}
+
+// TranslateFirst works the same as LocaleTranslator.TranslateFirst for the language of the translator.
+func (t *LocalizedTranslator) TranslateFirst(errorMap validate.ErrorMap, fallback ...ErrorTranslator) (map[string]string, bool) {
+	return t.translator.translate(t.locale, errorMap, true, fallback)
+}
+
+// TranslateDetails works the same as LocaleTranslator.TranslateDetails for the language of the translator.
+func (t *LocalizedTranslator) TranslateDetails(errorMap validate.ErrorMap, fallback ...ErrorTranslator) (map[string][]Translation, bool) {
+	return t.translator.translateErrorMap(t.locale, errorMap, false, fallback)
+}
+
+// TranslateFirstDetails works the same as LocaleTranslator.TranslateFirstDetails for the language of the translator.
+func (t *LocalizedTranslator) TranslateFirstDetails(errorMap validate.ErrorMap, fallback ...ErrorTranslator) (map[string][]Translation, bool) {
+	return t.translator.translateErrorMap(t.locale, errorMap, true, fallback)
+}
+
+// localeTranslations are the translations of a single language
+type localeTranslations struct {
+	locale     language.Tag
+	translator FieldErrorTranslator
+	patterns   patternIndex
+}
+
+// chain returns the translators to try for a language, the language itself followed by its parents and the
+// default language with its parents.
+func (lt *LocaleTranslator) chain(locale language.Tag) []localeTranslations {
+	var (
+		chain []localeTranslations
+		seen  = make(map[language.Tag]bool)
+	)
+
+	for _, tag := range []language.Tag{locale, lt.defaultLocale} {
+		for {
+			if ft, ok := lt.translators[tag]; ok && !seen[tag] {
+				chain = append(chain, localeTranslations{locale: tag, translator: ft, patterns: lt.patterns[tag].get(ft)})
+				seen[tag] = true
+			}
+
+			if tag == language.Und {
+				break
+			}
+			tag = tag.Parent()
+		}
+	}
+	return chain
+}
@@ -0,0 +1,219 @@
+package errortranslator_test
+
+import (
+	"sync"
+
+	errortranslator "github.com/mbict/go-errortranslator"
+	validate "github.com/mbict/go-validate"
+	"golang.org/x/text/language"
+	. "gopkg.in/check.v1"
+)
+
+type LocaleTranslatorSuite struct{}
+
+var _ = Suite(&LocaleTranslatorSuite{})
+
+func newLocaleTranslator() *errortranslator.LocaleTranslator {
+	lt := errortranslator.NewLocaleTranslator(language.English)
+	lt.Locale(language.English).
+		AddTranslation("A", validate.ErrRequired, "A is required").
+		AddTranslation("A", validate.ErrMin, "A is too short").
+		SetFallbackTranslation(validate.ErrMax, "too long").
+		SetFallbackDefaultTranslation("unknown error")
+
+	lt.Locale(language.Dutch).
+		AddTranslation("A", validate.ErrRequired, "A is verplicht").
+		SetFallbackTranslation(validate.ErrMax, "te lang")
+
+	lt.AddLocale(language.MustParse("nl-BE"), errortranslator.New().
+		AddTranslation("A", validate.ErrRequired, "A is vereist"))
+
+	lt.Locale(language.German).
+		AddTranslation("A", validate.ErrRequired, "A ist erforderlich").
+		SetDefaultTranslation("A", "A ist ungültig")
+	return lt
+}
+
+func (s *LocaleTranslatorSuite) TestLocales(c *C) {
+	lt := newLocaleTranslator()
+
+	c.Assert(lt.DefaultLocale(), Equals, language.English)
+	c.Assert(lt.Locales(), DeepEquals, []language.Tag{
+		language.English,
+		language.Dutch,
+		language.MustParse("nl-BE"),
+		language.German,
+	})
+}
+
+func (s *LocaleTranslatorSuite) TestMatch(c *C) {
+	lt := newLocaleTranslator()
+
+	tests := []struct {
+		Accept   string
+		Expected language.Tag
+	}{
+		{Accept: "nl", Expected: language.Dutch},
+		{Accept: "nl-BE", Expected: language.MustParse("nl-BE")},
+		{Accept: "nl-NL", Expected: language.Dutch},
+		{Accept: "de-AT", Expected: language.German},
+		{Accept: "en-GB", Expected: language.English},
+		{Accept: "fr", Expected: language.English},
+		{Accept: "fr-FR,fr;q=0.9,de;q=0.8", Expected: language.German},
+		{Accept: "", Expected: language.English},
+		{Accept: "invalid;;", Expected: language.English},
+	}
+
+	for _, test := range tests {
+		c.Assert(lt.MatchAcceptLanguage(test.Accept), Equals, test.Expected, Commentf(test.Accept))
+	}
+
+	c.Assert(lt.Match(language.French, language.Dutch), Equals, language.Dutch)
+	c.Assert(errortranslator.NewLocaleTranslator(language.English).Match(language.Dutch), Equals, language.English)
+}
+
+func (s *LocaleTranslatorSuite) TestConcurrentMatch(c *C) {
+	lt := newLocaleTranslator()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			lt.MatchAcceptLanguage("nl-BE")
+			lt.Translate(language.German, validate.ErrorMap{"A": validate.Errors{validate.ErrRequired}})
+		}()
+	}
+	wg.Wait()
+
+	c.Assert(lt.MatchAcceptLanguage("de-AT"), Equals, language.German)
+}
+
+func (s *LocaleTranslatorSuite) TestTranslate(c *C) {
+	lt := newLocaleTranslator()
+	errs := validate.ErrorMap{
+		"A": validate.Errors{validate.ErrRequired, validate.ErrMin},
+		"B": validate.Errors{validate.ErrMax},
+	}
+
+	tests := []struct {
+		Description string
+		Locale      language.Tag
+		Expected    map[string]string
+	}{
+		{
+			Description: "default locale",
+			Locale:      language.English,
+			Expected: map[string]string{
+				"A": "A is required, A is too short",
+				"B": "too long",
+			},
+		}, {
+			Description: "missing translations fallback to the default locale",
+			Locale:      language.Dutch,
+			Expected: map[string]string{
+				"A": "A is verplicht, A is too short",
+				"B": "te lang",
+			},
+		}, {
+			Description: "parent locale fallback",
+			Locale:      language.MustParse("nl-BE"),
+			Expected: map[string]string{
+				"A": "A is vereist, A is too short",
+				"B": "te lang",
+			},
+		}, {
+			Description: "field default of the locale has precedence over the default locale",
+			Locale:      language.German,
+			Expected: map[string]string{
+				"A": "A ist erforderlich, A ist ungültig",
+				"B": "too long",
+			},
+		}, {
+			Description: "unknown locale uses the default locale",
+			Locale:      language.French,
+			Expected: map[string]string{
+				"A": "A is required, A is too short",
+				"B": "too long",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		translated, ok := lt.Translate(test.Locale, errs)
+
+		c.Assert(ok, Equals, true, Commentf(test.Description))
+		c.Assert(translated, DeepEquals, test.Expected, Commentf(test.Description))
+	}
+}
+
+func (s *LocaleTranslatorSuite) TestTranslateRegionalFallback(c *C) {
+	lt := errortranslator.NewLocaleTranslator(language.English)
+	lt.Locale(language.English).
+		AddTranslation("B", validate.ErrRequired, "B is required").
+		SetFallbackDefaultTranslation("error")
+	lt.Locale(language.Dutch).
+		AddTranslation("A", validate.ErrRequired, "A verplicht")
+	lt.Locale(language.MustParse("nl-BE")).
+		SetFallbackDefaultTranslation("fout")
+
+	translations, ok := lt.TranslateDetails(language.MustParse("nl-BE"), validate.ErrorMap{
+		"A": validate.Errors{validate.ErrRequired},
+		"B": validate.Errors{validate.ErrRequired},
+		"C": validate.Errors{validate.ErrRequired},
+	})
+
+	c.Assert(ok, Equals, true)
+	c.Assert(translations, DeepEquals, map[string][]errortranslator.Translation{
+		"A": {{Code: "required", Message: "A verplicht", MatchedKey: "A", Err: validate.ErrRequired}},
+		"B": {{Code: "required", Message: "B is required", MatchedKey: "B", Err: validate.ErrRequired}},
+		"C": {{Code: "default", Message: "fout", UsedFallback: true, Err: validate.ErrRequired}},
+	})
+}
+
+func (s *LocaleTranslatorSuite) TestTranslateFirstAcceptLanguage(c *C) {
+	lt := newLocaleTranslator()
+
+	translated, ok := lt.TranslateFirstAcceptLanguage("nl-BE,nl;q=0.9,en;q=0.8", validate.ErrorMap{
+		"A": validate.Errors{validate.ErrMin, validate.ErrRequired},
+	})
+	c.Assert(ok, Equals, true)
+	c.Assert(translated, DeepEquals, map[string]string{"A": "A is too short"})
+
+	translated, ok = lt.TranslateAcceptLanguage("de", validate.ErrorMap{
+		"A": validate.Errors{validate.ErrRequired},
+	})
+	c.Assert(ok, Equals, true)
+	c.Assert(translated, DeepEquals, map[string]string{"A": "A ist erforderlich"})
+}
+
+func (s *LocaleTranslatorSuite) TestTranslateFallback(c *C) {
+	lt := errortranslator.NewLocaleTranslator(language.English)
+	lt.Locale(language.Dutch).AddTranslation("A", validate.ErrRequired, "A is verplicht")
+
+	fallback := errortranslator.ErrorTranslator{validate.ErrMin: "fallback min"}
+	translated, ok := lt.Translate(language.Dutch, validate.ErrorMap{
+		"A": validate.Errors{validate.ErrRequired, validate.ErrMin},
+		"B": validate.Errors{validate.ErrMax},
+	}, fallback)
+
+	c.Assert(ok, Equals, false)
+	c.Assert(translated, DeepEquals, map[string]string{"A": "A is verplicht, fallback min"})
+}
+
+func (s *LocaleTranslatorSuite) TestForLocale(c *C) {
+	lt := errortranslator.NewLocaleTranslator(language.English)
+	lt.Locale(language.English).AddTranslation("name", validate.ErrRequired, "The name is required")
+	lt.Locale(language.Dutch).AddTranslation("name", validate.ErrRequired, "De naam is verplicht")
+
+	t := lt.ForLocale(language.MustParse("nl-BE"))
+	c.Assert(t.Locale(), Equals, language.Dutch)
+
+	result, ok := t.Translate(validate.ErrorMap{"name": validate.Errors{validate.ErrRequired}})
+	c.Assert(ok, Equals, true)
+	c.Assert(result, DeepEquals, map[string]string{"name": "De naam is verplicht"})
+
+	details, ok := lt.ForLocale(language.German).TranslateFirstDetails(validate.ErrorMap{"name": validate.Errors{validate.ErrRequired}})
+	c.Assert(ok, Equals, true)
+	c.Assert(details["name"][0].Message, Equals, "The name is required")
+}
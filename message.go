@@ -0,0 +1,368 @@
+package errortranslator
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/text/language"
+)
+
+// ErrorParams can be implemented by errors to provide the values for the placeholders in a translation.
+// A translation like "must be at least {min} characters" is rendered with the value returned for the key "min".
+type ErrorParams interface {
+	Params() map[string]interface{}
+}
+
+// ErrorValue can be implemented by errors to provide the offending value, available as the {value} placeholder.
+type ErrorValue interface {
+	Value() interface{}
+}
+
+// MessageError is returned (or used as panic value) when a translation message is malformed.
+type MessageError struct {
+	Message string
+	Offset  int
+	Reason  string
+}
+
+func (e *MessageError) Error() string {
+	return fmt.Sprintf("errortranslator: malformed message %q at offset %d: %s", e.Message, e.Offset, e.Reason)
+}
+
+// segment is a part of a parsed message, either literal text, a placeholder, a plural placeholder or the number sign
+// inside a plural variant.
+type segment struct {
+	text   string
+	param  string
+	plural map[string]parsedMessage
+	number bool
+	raw    string
+}
+
+// parsedMessage is a translation message split in literal text and placeholders, it is not modified after parsing
+// because it is shared through the cache
+type parsedMessage []segment
+
+// ValidateMessage checks if the translation message is well formed.
+// Placeholders are written as {name}, where name consists of letters, digits and underscores.
+// Plural placeholders select a variant by the plural category of the parameter value according to the CLDR rules of
+// the language, e.g. `{count, plural, =0 {no items} one {# item} other {# items}}`. The categories are zero, one,
+// two, few, many and other, `=N` matches the exact value N. The other variant is required, the `#` in a variant is
+// replaced with the value.
+// A literal brace, backslash or number sign can be escaped with a backslash, e.g. `\{`, `\}`, `\\` and `\#`.
+func ValidateMessage(msg string) error {
+	_, err := cachedMessage(msg)
+	return err
+}
+
+// maxParsedMessages is the number of messages the cache holds before it is emptied
+const maxParsedMessages = 1024
+
+// messageCache caches the parsed messages by their text, translations are validated when they are added and parsed
+// only once for rendering.
+type messageCache struct {
+	messages sync.Map
+	size     atomic.Int64
+}
+
+// parsedMessages is the current cache. A full cache is replaced with a empty cache, the messages of replaced or
+// reloaded translations do not stay in memory.
+var parsedMessages atomic.Pointer[messageCache]
+
+func init() {
+	parsedMessages.Store(&messageCache{})
+}
+
+// cachedMessage returns the parsed message from the cache, a message that is not cached yet is parsed and added to the
+// cache. Messages without placeholders or escapes and malformed messages are not cached.
+func cachedMessage(msg string) (parsedMessage, error) {
+	if !strings.ContainsAny(msg, `{}\`) {
+		return parseMessage(msg)
+	}
+
+	cache := parsedMessages.Load()
+	if m, ok := cache.messages.Load(msg); ok {
+		return m.(parsedMessage), nil
+	}
+
+	m, err := parseMessage(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, loaded := cache.messages.LoadOrStore(msg, m); !loaded && cache.size.Add(1) >= maxParsedMessages {
+		parsedMessages.CompareAndSwap(cache, &messageCache{})
+	}
+	return m, nil
+}
+
+func parseMessage(msg string) (parsedMessage, error) {
+	p := &messageParser{msg: msg}
+	m, err := p.parse(false)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos < len(msg) {
+		return nil, p.errorf(p.pos, "unexpected closing brace")
+	}
+	return m, nil
+}
+
+// messageParser is a recursive descent parser for translation messages
+type messageParser struct {
+	msg string
+	pos int
+}
+
+func (p *messageParser) errorf(offset int, format string, args ...interface{}) error {
+	return &MessageError{Message: p.msg, Offset: offset, Reason: fmt.Sprintf(format, args...)}
+}
+
+// parse parses until the end of the message or a unmatched closing brace, which is left unconsumed.
+func (p *messageParser) parse(inPlural bool) (parsedMessage, error) {
+	var (
+		result parsedMessage
+		text   strings.Builder
+	)
+
+	flush := func() {
+		if text.Len() > 0 {
+			result = append(result, segment{text: text.String()})
+			text.Reset()
+		}
+	}
+
+	for p.pos < len(p.msg) {
+		switch ch := p.msg[p.pos]; {
+		case ch == '\\':
+			if p.pos+1 < len(p.msg) && strings.IndexByte(`{}\#`, p.msg[p.pos+1]) != -1 {
+				p.pos++
+			}
+			text.WriteByte(p.msg[p.pos])
+			p.pos++
+		case ch == '#' && inPlural:
+			flush()
+			result = append(result, segment{number: true})
+			p.pos++
+		case ch == '{':
+			flush()
+			s, err := p.parsePlaceholder()
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, s)
+		case ch == '}':
+			flush()
+			return result, nil
+		default:
+			text.WriteByte(ch)
+			p.pos++
+		}
+	}
+
+	flush()
+	return result, nil
+}
+
+// parsePlaceholder parses a {name} or {name, plural, ...} placeholder, the current position is the opening brace.
+func (p *messageParser) parsePlaceholder() (segment, error) {
+	start := p.pos
+	p.pos++
+
+	end := strings.IndexAny(p.msg[p.pos:], ",}")
+	if end == -1 {
+		return segment{}, p.errorf(start, "unclosed placeholder")
+	}
+
+	name := strings.TrimSpace(p.msg[p.pos : p.pos+end])
+	if reason := validateParamName(name); reason != "" {
+		return segment{}, p.errorf(p.pos, "%s", reason)
+	}
+	p.pos += end
+
+	if p.msg[p.pos] == '}' {
+		p.pos++
+		return segment{param: name, raw: p.msg[start:p.pos]}, nil
+	}
+
+	//plural placeholder
+	p.pos++
+	end = strings.IndexByte(p.msg[p.pos:], ',')
+	if end == -1 || strings.TrimSpace(p.msg[p.pos:p.pos+end]) != "plural" {
+		return segment{}, p.errorf(p.pos, "unknown placeholder type, only plural is supported")
+	}
+	p.pos += end + 1
+
+	variants := make(map[string]parsedMessage)
+	for {
+		p.skipSpaces()
+		if p.pos >= len(p.msg) {
+			return segment{}, p.errorf(start, "unclosed plural placeholder")
+		}
+
+		if p.msg[p.pos] == '}' {
+			break
+		}
+
+		selectorStart := p.pos
+		for p.pos < len(p.msg) && p.msg[p.pos] != '{' && p.msg[p.pos] != ' ' && p.msg[p.pos] != '}' {
+			p.pos++
+		}
+
+		selector := p.msg[selectorStart:p.pos]
+		if !validPluralSelector(selector) {
+			return segment{}, p.errorf(selectorStart, "invalid plural selector %q", selector)
+		}
+
+		if _, ok := variants[selector]; ok {
+			return segment{}, p.errorf(selectorStart, "duplicate plural selector %q", selector)
+		}
+
+		p.skipSpaces()
+		if p.pos >= len(p.msg) || p.msg[p.pos] != '{' {
+			return segment{}, p.errorf(p.pos, "expected plural variant after selector %q", selector)
+		}
+
+		variantStart := p.pos
+		p.pos++
+		variant, err := p.parse(true)
+		if err != nil {
+			return segment{}, err
+		}
+
+		if p.pos >= len(p.msg) {
+			return segment{}, p.errorf(variantStart, "unclosed plural variant")
+		}
+		p.pos++
+		variants[selector] = variant
+	}
+
+	if _, ok := variants["other"]; !ok {
+		return segment{}, p.errorf(start, "plural placeholder without other variant")
+	}
+
+	p.pos++
+	return segment{param: name, plural: variants, raw: p.msg[start:p.pos]}, nil
+}
+
+func (p *messageParser) skipSpaces() {
+	for p.pos < len(p.msg) && (p.msg[p.pos] == ' ' || p.msg[p.pos] == '\t' || p.msg[p.pos] == '\n') {
+		p.pos++
+	}
+}
+
+func validateParamName(name string) string {
+	if name == "" {
+		return "empty placeholder name"
+	}
+
+	for i, ch := range name {
+		switch {
+		case ch == '_', ch >= 'a' && ch <= 'z', ch >= 'A' && ch <= 'Z':
+		case ch >= '0' && ch <= '9' && i > 0:
+		default:
+			return fmt.Sprintf("invalid character %q in placeholder name", ch)
+		}
+	}
+	return ""
+}
+
+// render replaces the placeholders with the values from params.
+// Placeholders without a value are left untouched so they are visible in the output.
+func (m parsedMessage) render(locale language.Tag, params map[string]interface{}) string {
+	var result strings.Builder
+	m.renderTo(&result, locale, params, nil)
+	return result.String()
+}
+
+func (m parsedMessage) renderTo(result *strings.Builder, locale language.Tag, params map[string]interface{}, number interface{}) {
+	for _, s := range m {
+		switch {
+		case s.number:
+			fmt.Fprint(result, number)
+		case s.param == "":
+			result.WriteString(s.text)
+		default:
+			value, ok := params[s.param]
+			if !ok {
+				result.WriteString(s.raw)
+				continue
+			}
+
+			if s.plural == nil {
+				fmt.Fprint(result, value)
+				continue
+			}
+
+			variant, ok := selectPluralVariant(locale, value, s.plural)
+			if !ok {
+				result.WriteString(s.raw)
+				continue
+			}
+			variant.renderTo(result, locale, params, value)
+		}
+	}
+}
+
+// renderMessage renders the translation with the parameters extracted from the error.
+// Messages without placeholders or escapes are returned as is, a malformed message is returned unrendered.
+func renderMessage(translation string, ctx messageContext, err error) string {
+	if !strings.ContainsAny(translation, `{}\`) {
+		return translation
+	}
+
+	m, perr := cachedMessage(translation)
+	if perr != nil {
+		return translation
+	}
+	return m.render(ctx.locale, messageParams(ctx, err))
+}
+
+// messageContext holds what is being translated, used when rendering the message
+type messageContext struct {
+	field  string
+	label  string
+	locale language.Tag
+}
+
+// messageParams collects the placeholder values for a error, the values of the outermost errors have precedence.
+// The label is the display name of the field (see Labels), the field name when the field has no label.
+func messageParams(ctx messageContext, err error) map[string]interface{} {
+	label := ctx.label
+	if label == "" {
+		label = ctx.field
+	}
+
+	params := map[string]interface{}{
+		"field": ctx.field,
+		"label": label,
+	}
+
+	walkErrors(err, func(err error) bool {
+		if p, ok := err.(ErrorParams); ok {
+			for name, value := range p.Params() {
+				if _, ok := params[name]; !ok {
+					params[name] = value
+				}
+			}
+		}
+
+		if v, ok := err.(ErrorValue); ok {
+			if _, ok := params["value"]; !ok {
+				params["value"] = v.Value()
+			}
+		}
+		return false
+	})
+	return params
+}
+
+// mustValidateMessage panics when the message is malformed, used to fail early when registering translations.
+func mustValidateMessage(msg string) {
+	if err := ValidateMessage(msg); err != nil {
+		panic(err)
+	}
+}
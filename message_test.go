@@ -0,0 +1,169 @@
+package errortranslator_test
+
+import (
+	"fmt"
+
+	errortranslator "github.com/mbict/go-errortranslator"
+	validate "github.com/mbict/go-validate"
+	. "gopkg.in/check.v1"
+)
+
+type MessageSuite struct{}
+
+var _ = Suite(&MessageSuite{})
+
+type lengthError struct {
+	Min   int
+	Max   int
+	Input string
+}
+
+func (e lengthError) Error() string { return "invalid length" }
+
+func (e lengthError) Params() map[string]interface{} {
+	return map[string]interface{}{"min": e.Min, "max": e.Max}
+}
+
+func (e lengthError) Value() interface{} { return e.Input }
+
+func (s *MessageSuite) TestValidateMessage(c *C) {
+	tests := []struct {
+		Message string
+		Error   string
+	}{
+		{Message: "plain message"},
+		{Message: "must be at least {min} characters"},
+		{Message: "{field} must be between {min} and {max}"},
+		{Message: `escaped \{min\} and \\`},
+		{
+			Message: "unclosed {min",
+			Error:   `errortranslator: malformed message "unclosed {min" at offset 9: unclosed placeholder`,
+		}, {
+			Message: "empty {}",
+			Error:   `errortranslator: malformed message "empty {}" at offset 7: empty placeholder name`,
+		}, {
+			Message: "invalid {min max}",
+			Error:   `errortranslator: malformed message "invalid {min max}" at offset 9: invalid character ' ' in placeholder name`,
+		}, {
+			Message: "closing } only",
+			Error:   `errortranslator: malformed message "closing } only" at offset 8: unexpected closing brace`,
+		},
+	}
+
+	for _, test := range tests {
+		err := errortranslator.ValidateMessage(test.Message)
+		if test.Error == "" {
+			c.Assert(err, IsNil, Commentf(test.Message))
+		} else {
+			c.Assert(err, ErrorMatches, test.Error, Commentf(test.Message))
+		}
+	}
+}
+
+func (s *MessageSuite) TestAddTranslationMalformedMessage(c *C) {
+	et := errortranslator.ErrorTranslator{}
+	c.Assert(func() { et.AddTranslation(validate.ErrMin, "at least {min") }, PanicMatches, `errortranslator: malformed message .*`)
+
+	ft := errortranslator.New()
+	c.Assert(func() { ft.AddTranslation("A", validate.ErrMin, "at least {min") }, PanicMatches, `errortranslator: malformed message .*`)
+}
+
+func (s *MessageSuite) TestValidate(c *C) {
+	ft := errortranslator.FieldErrorTranslator{
+		"A": errortranslator.ErrorTranslator{
+			validate.ErrMin: "at least {min}",
+		},
+	}
+	c.Assert(ft.Validate(), IsNil)
+
+	ft["B"] = errortranslator.ErrorTranslator{validate.ErrMax: "at most {max"}
+	c.Assert(ft.Validate(), ErrorMatches, `errortranslator: malformed message "at most {max" .*`)
+}
+
+func (s *MessageSuite) TestRenderCachedMessage(c *C) {
+	et := errortranslator.ErrorTranslator{
+		validate.ErrMin: "must be at least {min} characters",
+		validate.ErrMax: "at most {max",
+	}
+
+	for i := 0; i < 2; i++ {
+		translated, _ := et.TranslateError(fmt.Errorf("%w: %w", validate.ErrMin, lengthError{Min: i}))
+		c.Assert(translated, Equals, fmt.Sprintf("must be at least %d characters", i))
+
+		//malformed messages are not cached and returned unrendered
+		c.Assert(errortranslator.ValidateMessage("at most {max"), NotNil)
+		translated, _ = et.TranslateError(fmt.Errorf("%w: %w", validate.ErrMax, lengthError{Max: i}))
+		c.Assert(translated, Equals, "at most {max")
+	}
+}
+
+func (s *MessageSuite) TestRenderManyMessages(c *C) {
+	//more messages than the cache holds, the cache is emptied and the messages are parsed again
+	for i := 0; i < 3000; i++ {
+		et := errortranslator.ErrorTranslator{validate.ErrMin: fmt.Sprintf("message %d needs {min}", i)}
+
+		translated, _ := et.TranslateError(fmt.Errorf("%w: %w", validate.ErrMin, lengthError{Min: i}))
+		c.Assert(translated, Equals, fmt.Sprintf("message %d needs %d", i, i))
+	}
+}
+
+func (s *MessageSuite) BenchmarkRenderPluralMessage(c *C) {
+	et := errortranslator.New().
+		AddTranslation("A", validate.ErrMin, "{field} needs {min, plural, one {# character} other {# characters}}")
+	errorMap := validate.ErrorMap{"A": validate.Errors{fmt.Errorf("%w: %w", validate.ErrMin, lengthError{Min: 3})}}
+
+	for i := 0; i < c.N; i++ {
+		et.Translate(errorMap)
+	}
+}
+
+func (s *MessageSuite) TestTranslateErrorWithParams(c *C) {
+	et := errortranslator.ErrorTranslator{
+		validate.ErrMin: "must be at least {min} characters",
+	}
+	errortranslator.AddTypeTranslation[lengthError](et, `{value} must be between {min} and {max} characters \{{unknown}\}`)
+
+	tests := []struct {
+		Description string
+		Error       error
+		Expected    string
+	}{
+		{
+			Description: "params from error",
+			Error:       lengthError{Min: 2, Max: 5, Input: "abcdefg"},
+			Expected:    "abcdefg must be between 2 and 5 characters {{unknown}}",
+		}, {
+			Description: "params from wrapped error",
+			Error:       fmt.Errorf("%w: %w", validate.ErrMin, lengthError{Min: 3}),
+			Expected:    "must be at least 3 characters",
+		}, {
+			Description: "missing params are untouched",
+			Error:       validate.ErrMin,
+			Expected:    "must be at least {min} characters",
+		},
+	}
+
+	for _, test := range tests {
+		translated, ok := et.TranslateError(test.Error)
+
+		c.Assert(ok, Equals, true, Commentf(test.Description))
+		c.Assert(translated, Equals, test.Expected, Commentf(test.Description))
+	}
+}
+
+func (s *MessageSuite) TestTranslateFieldParams(c *C) {
+	ft := errortranslator.New().
+		AddTranslation("A", validate.ErrMin, "{field} is too short, at least {min} characters").
+		SetFallbackTranslation(validate.ErrRequired, "{field} is required")
+
+	translated, ok := ft.Translate(validate.ErrorMap{
+		"A": validate.Errors{lengthError{Min: 4}, fmt.Errorf("%w: %w", validate.ErrMin, lengthError{Min: 4})},
+		"B": validate.Errors{validate.ErrRequired},
+	})
+
+	c.Assert(ok, Equals, true)
+	c.Assert(translated, DeepEquals, map[string]string{
+		"A": "A is too short, at least 4 characters",
+		"B": "B is required",
+	})
+}
@@ -0,0 +1,201 @@
+package errortranslator
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// FieldTranslations are the translations of a single field
+type FieldTranslations struct {
+	Field        string
+	Translations []Translation
+}
+
+// OrderedTranslations are the translations of a error map in a fixed field order, see Ordered.
+type OrderedTranslations []FieldTranslations
+
+// First returns the field and the first translation of the first field, the first error of the whole form.
+func (o OrderedTranslations) First() (string, Translation, bool) {
+	for _, f := range o {
+		if len(f.Translations) > 0 {
+			return f.Field, f.Translations[0], true
+		}
+	}
+	return "", Translation{}, false
+}
+
+// Ordered returns the translations of the fields (see TranslateDetails) in the order of the field paths.
+// A order path ranks its parents too, the order `Name`, `Address.Street`, `Address.City`, `Items.*.Price` puts all
+// the errors of the address after the name and before the items. A `*` segment matches any segment, fields matched by
+// the same wildcard are ordered by their segment, numeric segments by their value (`Items.2` before `Items.10`).
+// Fields without order come last in sorted order, without an order all fields are sorted. FieldOrder returns the order
+// of the fields of a struct.
+func Ordered(translations map[string][]Translation, order []string) OrderedTranslations {
+	fields := make([]string, 0, len(translations))
+	for field := range translations {
+		fields = append(fields, field)
+	}
+	SortFields(fields, order)
+
+	result := make(OrderedTranslations, 0, len(fields))
+	for _, field := range fields {
+		result = append(result, FieldTranslations{Field: field, Translations: translations[field]})
+	}
+	return result
+}
+
+// FirstError returns the field and the first translation of the first field in the order, see Ordered.
+func FirstError(translations map[string][]Translation, order []string) (string, Translation, bool) {
+	return Ordered(translations, order).First()
+}
+
+// SortFields sorts field names in the order of the field paths, see Ordered.
+func SortFields(fields []string, order []string) {
+	tree := newOrderNode()
+	for _, path := range order {
+		tree.add(splitFieldPath(path))
+	}
+
+	sort.SliceStable(fields, func(i, j int) bool {
+		return tree.less(splitFieldPath(fields[i]), splitFieldPath(fields[j]))
+	})
+}
+
+// orderNode is a segment of the order paths, the children are ranked in the order they are added
+type orderNode struct {
+	rank     map[string]int
+	children map[string]*orderNode
+}
+
+func newOrderNode() *orderNode {
+	return &orderNode{
+		rank:     make(map[string]int),
+		children: make(map[string]*orderNode),
+	}
+}
+
+func (n *orderNode) add(segments []string) {
+	for _, segment := range segments {
+		child, ok := n.children[segment]
+		if !ok {
+			child = newOrderNode()
+			n.rank[segment] = len(n.rank)
+			n.children[segment] = child
+		}
+		n = child
+	}
+}
+
+// child returns the node of the segment and its rank, a explicit segment has precedence over a wildcard.
+// Unknown segments rank after all the known segments.
+func (n *orderNode) child(segment string) (*orderNode, int) {
+	if n == nil {
+		return nil, 0
+	}
+
+	if child, ok := n.children[segment]; ok {
+		return child, n.rank[segment]
+	}
+
+	if child, ok := n.children["*"]; ok {
+		return child, n.rank["*"]
+	}
+	return nil, len(n.rank)
+}
+
+func (n *orderNode) less(a []string, b []string) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] == b[i] {
+			n, _ = n.child(a[i])
+			continue
+		}
+
+		_, rankA := n.child(a[i])
+		_, rankB := n.child(b[i])
+		if rankA != rankB {
+			return rankA < rankB
+		}
+		return lessSegment(a[i], b[i])
+	}
+	return len(a) < len(b)
+}
+
+// lessSegment compares two segments, numeric segments by their value
+func lessSegment(a string, b string) bool {
+	na, errA := strconv.Atoi(a)
+	nb, errB := strconv.Atoi(b)
+	switch {
+	case errA == nil && errB == nil:
+		return na < nb
+	case errA == nil:
+		return true
+	case errB == nil:
+		return false
+	}
+	return a < b
+}
+
+// FieldOrder returns the field paths of a struct in declaration order, to be used with Ordered.
+// Nested structs are added with their path, `Address.Street`, the fields of embedded structs are added as fields of
+// the struct itself. Slices, arrays and maps of structs are added with a wildcard for the index, `Items.*.Price`.
+// A field with the type of a struct it is part of is added without its fields.
+// v is a struct, a pointer to a struct or a reflect.Type of one of these.
+func FieldOrder(v interface{}) []string {
+	t, ok := v.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(v)
+	}
+
+	var order []string
+	walkStructFields(indirectType(t), "", make(map[reflect.Type]bool), func(path string, _ reflect.StructField) {
+		order = append(order, path)
+	})
+	return order
+}
+
+// walkStructFields calls fn for every exported field of the struct in declaration order with the path of the field.
+// See FieldOrder for the paths of nested and embedded structs, slices, arrays and maps.
+func walkStructFields(t reflect.Type, prefix string, visiting map[reflect.Type]bool, fn func(path string, f reflect.StructField)) {
+	if t == nil || t.Kind() != reflect.Struct || visiting[t] {
+		return
+	}
+
+	//the fields of a recursive type are not added again
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() && !f.Anonymous {
+			continue
+		}
+
+		ft := indirectType(f.Type)
+		if f.Anonymous && ft.Kind() == reflect.Struct {
+			walkStructFields(ft, prefix, visiting, fn)
+			continue
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+
+		path := prefix + f.Name
+		fn(path, f)
+
+		switch ft.Kind() {
+		case reflect.Struct:
+			walkStructFields(ft, path+".", visiting, fn)
+		case reflect.Slice, reflect.Array, reflect.Map:
+			walkStructFields(indirectType(ft.Elem()), path+".*.", visiting, fn)
+		}
+	}
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
@@ -0,0 +1,102 @@
+package errortranslator_test
+
+import (
+	"reflect"
+	"time"
+
+	errortranslator "github.com/mbict/go-errortranslator"
+	validate "github.com/mbict/go-validate"
+	. "gopkg.in/check.v1"
+)
+
+type OrderSuite struct{}
+
+var _ = Suite(&OrderSuite{})
+
+type orderAudit struct {
+	Created time.Time
+}
+
+type orderLine struct {
+	Product  string
+	Quantity int
+}
+
+type order struct {
+	orderAudit
+	Name    string
+	secret  string
+	Address struct {
+		Street string
+		City   string
+	}
+	Lines  []*orderLine
+	Parent *order
+	Tags   map[string]string
+}
+
+func (s *OrderSuite) TestFieldOrder(c *C) {
+	expected := []string{
+		"Created",
+		"Name",
+		"Address", "Address.Street", "Address.City",
+		"Lines", "Lines.*.Product", "Lines.*.Quantity",
+		"Parent",
+		"Tags",
+	}
+
+	c.Assert(errortranslator.FieldOrder(order{}), DeepEquals, expected)
+	c.Assert(errortranslator.FieldOrder(&order{}), DeepEquals, expected)
+	c.Assert(errortranslator.FieldOrder(reflect.TypeOf(order{})), DeepEquals, expected)
+	c.Assert(errortranslator.FieldOrder("not a struct"), HasLen, 0)
+}
+
+func (s *OrderSuite) TestSortFields(c *C) {
+	fields := []string{"Tags.x", "Lines.10.Product", "Unknown", "Lines.2.Quantity", "Address.City", "Lines.2.Product",
+		"Name", "Address", "Extra", "Lines[1].Quantity"}
+
+	errortranslator.SortFields(fields, errortranslator.FieldOrder(order{}))
+
+	c.Assert(fields, DeepEquals, []string{"Name", "Address", "Address.City", "Lines[1].Quantity", "Lines.2.Product",
+		"Lines.2.Quantity", "Lines.10.Product", "Tags.x", "Extra", "Unknown"})
+
+	//without a order the fields are sorted
+	fields = []string{"B.10", "B.2", "A", "C"}
+	errortranslator.SortFields(fields, nil)
+
+	c.Assert(fields, DeepEquals, []string{"A", "B.2", "B.10", "C"})
+}
+
+func (s *OrderSuite) TestOrdered(c *C) {
+	translations, ok := errortranslator.New().
+		SetFallbackTranslation(validate.ErrRequired, "{field} is required").
+		SetFallbackTranslation(validate.ErrMin, "{field} is too short").
+		TranslateDetails(validate.ErrorMap{
+			"Lines.0.Product": validate.Errors{validate.ErrMin},
+			"Name":            validate.Errors{validate.ErrRequired, validate.ErrMin},
+			"Address.Street":  validate.Errors{validate.ErrRequired},
+		})
+	c.Assert(ok, Equals, true)
+
+	ordered := errortranslator.Ordered(translations, []string{"Name", "Address.Street", "Lines.*.Product"})
+
+	fields := []string{}
+	for _, f := range ordered {
+		fields = append(fields, f.Field)
+	}
+	c.Assert(fields, DeepEquals, []string{"Name", "Address.Street", "Lines.0.Product"})
+	c.Assert(ordered[0].Translations, HasLen, 2)
+
+	field, translation, ok := ordered.First()
+	c.Assert(ok, Equals, true)
+	c.Assert(field, Equals, "Name")
+	c.Assert(translation.Message, Equals, "Name is required")
+
+	field, translation, ok = errortranslator.FirstError(translations, []string{"Lines", "Address"})
+	c.Assert(ok, Equals, true)
+	c.Assert(field, Equals, "Lines.0.Product")
+	c.Assert(translation.Message, Equals, "Lines.0.Product is too short")
+
+	_, _, ok = errortranslator.FirstError(nil, nil)
+	c.Assert(ok, Equals, false)
+}
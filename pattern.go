@@ -0,0 +1,170 @@
+package errortranslator
+
+import (
+	"sort"
+	"strings"
+	"sync/atomic"
+)
+
+// fieldPattern is a field key with wildcards, split in segments.
+// A `*` segment matches exactly one segment of the field, a `**` segment matches zero or more segments.
+type fieldPattern struct {
+	key      string
+	segments []string
+	deep     bool
+	wildcard int
+	first    int
+}
+
+// isFieldPattern reports if the field key contains wildcards
+func isFieldPattern(key string) bool {
+	return strings.Contains(key, "*")
+}
+
+// splitFieldPath splits a field path in segments, both dots and brackets are separators:
+// `items[0].price` and `items.0.price` result in the segments items, 0 and price
+func splitFieldPath(path string) []string {
+	path = strings.NewReplacer("[", ".", "]", "").Replace(path)
+	return strings.Split(path, ".")
+}
+
+func newFieldPattern(key string) fieldPattern {
+	p := fieldPattern{key: key, segments: splitFieldPath(key), first: -1}
+	for i, segment := range p.segments {
+		if segment != "*" && segment != "**" {
+			continue
+		}
+
+		p.wildcard++
+		p.deep = p.deep || segment == "**"
+		if p.first == -1 {
+			p.first = i
+		}
+	}
+	return p
+}
+
+func (p fieldPattern) match(segments []string) bool {
+	return matchSegments(p.segments, segments)
+}
+
+func matchSegments(pattern []string, segments []string) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case "**":
+			for i := 0; i <= len(segments); i++ {
+				if matchSegments(pattern[1:], segments[i:]) {
+					return true
+				}
+			}
+			return false
+		case "*":
+			if len(segments) == 0 {
+				return false
+			}
+		default:
+			if len(segments) == 0 || pattern[0] != segments[0] {
+				return false
+			}
+		}
+		pattern, segments = pattern[1:], segments[1:]
+	}
+	return len(segments) == 0
+}
+
+// moreSpecific reports if pattern p has precedence over pattern o.
+// Single wildcards beat deep wildcards, then fewer wildcards win, then the pattern with the first wildcard furthest
+// to the right. Equal patterns are ordered by key to keep the order deterministic.
+func (p fieldPattern) moreSpecific(o fieldPattern) bool {
+	switch {
+	case p.deep != o.deep:
+		return !p.deep
+	case p.wildcard != o.wildcard:
+		return p.wildcard < o.wildcard
+	case p.first != o.first:
+		return p.first > o.first
+	}
+	return p.key < o.key
+}
+
+// matchKeys returns the keys that match the field, the most specific key first.
+// A exact match has precedence over all patterns, the patterns are the pattern index of the translator.
+func (ft FieldErrorTranslator) matchKeys(field string, patterns patternIndex) []string {
+	var keys []string
+	if _, ok := ft[field]; ok {
+		keys = append(keys, field)
+	}
+	return append(keys, patterns.match(field)...)
+}
+
+// patternIndex holds the parsed pattern keys of a map, the most specific pattern first. The index is built once so
+// matching a field does not scan, split and sort all the keys of the map for every error.
+type patternIndex []fieldPattern
+
+// newPatternIndex parses and sorts the pattern keys of the map
+func newPatternIndex[V any](m map[string]V) patternIndex {
+	var patterns patternIndex
+	for key := range m {
+		if isFieldPattern(key) {
+			patterns = append(patterns, newFieldPattern(key))
+		}
+	}
+
+	sort.Slice(patterns, func(i, j int) bool {
+		return patterns[i].moreSpecific(patterns[j])
+	})
+	return patterns
+}
+
+// match returns the pattern keys that match the field, the most specific pattern first.
+func (patterns patternIndex) match(field string) []string {
+	if len(patterns) == 0 {
+		return nil
+	}
+
+	var (
+		keys     []string
+		segments = splitFieldPath(field)
+	)
+	for _, p := range patterns {
+		if p.key != field && p.match(segments) {
+			keys = append(keys, p.key)
+		}
+	}
+	return keys
+}
+
+// patternCache holds the pattern index of a FieldErrorTranslator that can still be changed. The index is built when
+// the translations are used and is reset when they are handed out to be changed.
+type patternCache struct {
+	index atomic.Pointer[patternIndex]
+}
+
+// get returns the pattern index of the translations, the index is built when it is not present
+func (c *patternCache) get(ft FieldErrorTranslator) patternIndex {
+	if patterns := c.index.Load(); patterns != nil {
+		return *patterns
+	}
+
+	patterns := newPatternIndex(ft)
+	c.index.Store(&patterns)
+	return patterns
+}
+
+// reset removes the index, the next get builds a new index
+func (c *patternCache) reset() {
+	c.index.Store(nil)
+}
+
+// Match returns the key that is used to translate the errors of a field, a exact match or the most specific pattern.
+// Patterns are keys with wildcards, `*` matches a single segment and `**` any number of segments of the field name.
+// Segments are separated by dots or brackets, `items[*].price` matches `items[0].price` and `items.0.price`.
+// The precedence is: a exact match, patterns with only single wildcards, patterns with deep wildcards and finally
+// the fallback translations. When no key matches false is returned.
+func (ft FieldErrorTranslator) Match(field string) (string, bool) {
+	keys := ft.matchKeys(field, newPatternIndex(ft))
+	if len(keys) == 0 {
+		return "", false
+	}
+	return keys[0], true
+}
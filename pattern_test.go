@@ -0,0 +1,151 @@
+package errortranslator_test
+
+import (
+	errortranslator "github.com/mbict/go-errortranslator"
+	validate "github.com/mbict/go-validate"
+	"golang.org/x/text/language"
+	. "gopkg.in/check.v1"
+)
+
+type PatternSuite struct{}
+
+var _ = Suite(&PatternSuite{})
+
+func (s *PatternSuite) TestMatch(c *C) {
+	ft := errortranslator.FieldErrorTranslator{
+		"B.1":            errortranslator.ErrorTranslator{},
+		"B.*":            errortranslator.ErrorTranslator{},
+		"*.*":            errortranslator.ErrorTranslator{},
+		"items[*].price": errortranslator.ErrorTranslator{},
+		"items.*.*":      errortranslator.ErrorTranslator{},
+		"*.0.price":      errortranslator.ErrorTranslator{},
+		"**.email":       errortranslator.ErrorTranslator{},
+		"user.**":        errortranslator.ErrorTranslator{},
+		"":               errortranslator.ErrorTranslator{},
+	}
+
+	tests := []struct {
+		Field    string
+		Expected string
+		Ok       bool
+	}{
+		{Field: "B.1", Expected: "B.1", Ok: true},
+		{Field: "B.2", Expected: "B.*", Ok: true},
+		{Field: "C.2", Expected: "*.*", Ok: true},
+		{Field: "items[3].price", Expected: "items[*].price", Ok: true},
+		{Field: "items.3.price", Expected: "items[*].price", Ok: true},
+		{Field: "items[0].price", Expected: "items[*].price", Ok: true},
+		{Field: "other[0].price", Expected: "*.0.price", Ok: true},
+		{Field: "items[0].name", Expected: "items.*.*", Ok: true},
+		{Field: "email", Expected: "**.email", Ok: true},
+		{Field: "address.contact.email", Expected: "**.email", Ok: true},
+		{Field: "user.email", Expected: "*.*", Ok: true},
+		{Field: "user.name", Expected: "*.*", Ok: true},
+		{Field: "user.address.street", Expected: "user.**", Ok: true},
+		{Field: "B", Expected: "", Ok: false},
+		{Field: "B.1.2", Expected: "", Ok: false},
+	}
+
+	for _, test := range tests {
+		key, ok := ft.Match(test.Field)
+
+		c.Assert(ok, Equals, test.Ok, Commentf(test.Field))
+		c.Assert(key, Equals, test.Expected, Commentf(test.Field))
+	}
+}
+
+func (s *PatternSuite) TestTranslatePatterns(c *C) {
+	ft := errortranslator.FieldErrorTranslator{
+		"B.1": errortranslator.ErrorTranslator{
+			validate.ErrRequired: "b1 required translate",
+		},
+		"B.*": errortranslator.ErrorTranslator{
+			validate.ErrRequired: "b required translate",
+			validate.ErrMin:      "b min translate",
+		},
+		"**.email": errortranslator.ErrorTranslator{
+			validate.ErrRequired: "{field} email required translate",
+			validate.ErrMax:      "email max translate",
+		},
+		"": errortranslator.ErrorTranslator{
+			validate.ErrMax: "nil max default translate",
+		},
+	}
+
+	tests := []struct {
+		Description string
+		Errors      validate.ErrorMap
+		ExpectedOk  bool
+		Expected    map[string]string
+	}{
+		{
+			Description: "exact match has precedence",
+			Errors: validate.ErrorMap{
+				"B.1": validate.Errors{validate.ErrRequired},
+				"B.2": validate.Errors{validate.ErrRequired},
+			},
+			ExpectedOk: true,
+			Expected: map[string]string{
+				"B.1": "b1 required translate",
+				"B.2": "b required translate",
+			},
+		}, {
+			Description: "less specific pattern is tried before the fallback",
+			Errors: validate.ErrorMap{
+				"B.1": validate.Errors{validate.ErrMin, validate.ErrMax},
+			},
+			ExpectedOk: true,
+			Expected: map[string]string{
+				"B.1": "b min translate, nil max default translate",
+			},
+		}, {
+			Description: "deep wildcard",
+			Errors: validate.ErrorMap{
+				"user.contact.email": validate.Errors{validate.ErrRequired, validate.ErrMax},
+				"email":              validate.Errors{validate.ErrRequired},
+			},
+			ExpectedOk: true,
+			Expected: map[string]string{
+				"user.contact.email": "user.contact.email email required translate, email max translate",
+				"email":              "email email required translate",
+			},
+		}, {
+			Description: "no pattern matches",
+			Errors: validate.ErrorMap{
+				"C": validate.Errors{validate.ErrRequired, validate.ErrMax},
+			},
+			ExpectedOk: true,
+			Expected: map[string]string{
+				"C": "nil max default translate",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		translated, ok := ft.Translate(test.Errors)
+
+		c.Assert(ok, Equals, test.ExpectedOk, Commentf(test.Description))
+		c.Assert(translated, DeepEquals, test.Expected, Commentf(test.Description))
+	}
+}
+
+func (s *PatternSuite) TestPatternsAddedAfterTranslating(c *C) {
+	errorMap := validate.ErrorMap{"items[0].price": validate.Errors{validate.ErrMin}}
+
+	lt := errortranslator.NewLocaleTranslator(language.English)
+	lt.Locale(language.English).SetFallbackTranslation(validate.ErrMin, "too low")
+	translated, _ := lt.Translate(language.English, errorMap)
+	c.Assert(translated["items[0].price"], Equals, "too low")
+
+	lt.Locale(language.English).AddTranslation("items[*].price", validate.ErrMin, "The price is too low")
+	translated, _ = lt.Translate(language.English, errorMap)
+	c.Assert(translated["items[0].price"], Equals, "The price is too low")
+
+	st := errortranslator.NewSyncTranslator(errortranslator.New().SetFallbackTranslation(validate.ErrMin, "too low"))
+	translated, _ = st.Translate(errorMap)
+	c.Assert(translated["items[0].price"], Equals, "too low")
+
+	st.AddTranslation("items[*].price", validate.ErrMin, "The price is too low")
+	translated, _ = st.Translate(errorMap)
+	c.Assert(translated["items[0].price"], Equals, "The price is too low")
+}
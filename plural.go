@@ -0,0 +1,97 @@
+package errortranslator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+// pluralCategories are the CLDR plural categories in the order of the plural.Form values
+var pluralCategories = []string{"other", "zero", "one", "two", "few", "many"}
+
+// validPluralSelector checks if the selector is a CLDR plural category or a exact match (=N)
+func validPluralSelector(selector string) bool {
+	if strings.HasPrefix(selector, "=") {
+		_, err := strconv.ParseFloat(selector[1:], 64)
+		return err == nil
+	}
+
+	for _, category := range pluralCategories {
+		if selector == category {
+			return true
+		}
+	}
+	return false
+}
+
+// selectPluralVariant selects the variant for the numeric value, a exact match (=N) has precedence over the plural
+// category of the language. When the value is not a number no variant is selected.
+func selectPluralVariant(locale language.Tag, value interface{}, variants map[string]parsedMessage) (parsedMessage, bool) {
+	number, ok := formatNumber(value)
+	if !ok {
+		return nil, false
+	}
+
+	n, _ := strconv.ParseFloat(number, 64)
+	for selector, variant := range variants {
+		if strings.HasPrefix(selector, "=") {
+			if exact, _ := strconv.ParseFloat(selector[1:], 64); exact == n {
+				return variant, true
+			}
+		}
+	}
+
+	if variant, ok := variants[pluralCategory(locale, number)]; ok {
+		return variant, true
+	}
+	return variants["other"], true
+}
+
+// pluralCategory returns the CLDR plural category of the decimal number for the language.
+// The English rules are used when the language is undefined.
+func pluralCategory(locale language.Tag, number string) string {
+	if locale == language.Und {
+		locale = language.English
+	}
+
+	number = strings.TrimPrefix(number, "-")
+	integer, fraction := number, ""
+	if dot := strings.IndexByte(number, '.'); dot != -1 {
+		integer, fraction = number[:dot], number[dot+1:]
+	}
+	trimmed := strings.TrimRight(fraction, "0")
+
+	i := operand(integer)
+	form := plural.Cardinal.MatchPlural(locale, i, len(fraction), len(trimmed), operand(fraction), operand(trimmed))
+	return pluralCategories[form]
+}
+
+// operand converts the digits to a plural operand, large values are taken modulo 10,000,000 as allowed by MatchPlural.
+func operand(digits string) int {
+	if len(digits) > 7 {
+		digits = digits[len(digits)-7:]
+	}
+	n, _ := strconv.Atoi(digits)
+	return n
+}
+
+// formatNumber formats a numeric value as a plain decimal string, strings that contain a number are accepted as well.
+func formatNumber(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprint(v), true
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case string:
+		if _, err := strconv.ParseFloat(v, 64); err != nil || strings.ContainsAny(v, "eEnNxX") {
+			return "", false
+		}
+		return v, true
+	}
+	return "", false
+}
@@ -0,0 +1,127 @@
+package errortranslator_test
+
+import (
+	errortranslator "github.com/mbict/go-errortranslator"
+	validate "github.com/mbict/go-validate"
+	"golang.org/x/text/language"
+	. "gopkg.in/check.v1"
+)
+
+type PluralSuite struct{}
+
+var _ = Suite(&PluralSuite{})
+
+type countError struct {
+	Count interface{}
+}
+
+func (e countError) Error() string { return "too many items" }
+
+func (e countError) Params() map[string]interface{} {
+	return map[string]interface{}{"count": e.Count}
+}
+
+func (s *PluralSuite) TestValidatePluralMessage(c *C) {
+	tests := []struct {
+		Message string
+		Error   string
+	}{
+		{Message: "{count, plural, one {# item} other {# items}}"},
+		{Message: "{count,plural,=0{no items}one{{field} has # item}other{# items \\# {max}}}"},
+		{
+			Message: "{count, plural, one {# item}}",
+			Error:   `.* at offset 0: plural placeholder without other variant`,
+		}, {
+			Message: "{count, plural, several {# items} other {# items}}",
+			Error:   `.* at offset 16: invalid plural selector "several"`,
+		}, {
+			Message: "{count, plural, one {# item} one {# items} other {}}",
+			Error:   `.* at offset 29: duplicate plural selector "one"`,
+		}, {
+			Message: "{count, select, one {# item} other {# items}}",
+			Error:   `.* at offset 7: unknown placeholder type, only plural is supported`,
+		}, {
+			Message: "{count, plural, one {# item} other {# items}",
+			Error:   `.* at offset 0: unclosed plural placeholder`,
+		}, {
+			Message: "{count, plural, one {# item} other {# items",
+			Error:   `.* at offset 35: unclosed plural variant`,
+		}, {
+			Message: "{count, plural, one # item other {# items}}",
+			Error:   `.* at offset 20: expected plural variant after selector "one"`,
+		},
+	}
+
+	for _, test := range tests {
+		err := errortranslator.ValidateMessage(test.Message)
+		if test.Error == "" {
+			c.Assert(err, IsNil, Commentf(test.Message))
+		} else {
+			c.Assert(err, ErrorMatches, test.Error, Commentf(test.Message))
+		}
+	}
+}
+
+func (s *PluralSuite) TestTranslatePlural(c *C) {
+	et := errortranslator.ErrorTranslator{}
+	errortranslator.AddTypeTranslation[countError](et, "{count, plural, =0 {no items} one {# item} other {# items}}")
+
+	tests := []struct {
+		Count    interface{}
+		Expected string
+	}{
+		{Count: 0, Expected: "no items"},
+		{Count: 1, Expected: "1 item"},
+		{Count: uint8(2), Expected: "2 items"},
+		{Count: 1.5, Expected: "1.5 items"},
+		{Count: "1.0", Expected: "1.0 items"},
+		{Count: "many", Expected: "{count, plural, =0 {no items} one {# item} other {# items}}"},
+	}
+
+	for _, test := range tests {
+		translated, ok := et.TranslateError(countError{Count: test.Count})
+
+		c.Assert(ok, Equals, true)
+		c.Assert(translated, Equals, test.Expected, Commentf("%v", test.Count))
+	}
+}
+
+func (s *PluralSuite) TestTranslatePluralLocale(c *C) {
+	lt := errortranslator.NewLocaleTranslator(language.English)
+	lt.Locale(language.English).SetFallbackTranslation(errortranslator.TypeKey[countError](),
+		"{count, plural, one {# item} other {# items}}")
+	lt.Locale(language.Polish).SetFallbackTranslation(errortranslator.TypeKey[countError](),
+		"{count, plural, one {# element} few {# elementy} many {# elementów} other {# elementu}}")
+	lt.Locale(language.Arabic).SetFallbackTranslation(errortranslator.TypeKey[countError](),
+		"{count, plural, zero {zero} one {one} two {two} few {few} many {many} other {other}}")
+
+	tests := []struct {
+		Locale   language.Tag
+		Count    interface{}
+		Expected string
+	}{
+		{Locale: language.English, Count: 1, Expected: "1 item"},
+		{Locale: language.English, Count: 21, Expected: "21 items"},
+		{Locale: language.Polish, Count: 1, Expected: "1 element"},
+		{Locale: language.Polish, Count: 3, Expected: "3 elementy"},
+		{Locale: language.Polish, Count: 22, Expected: "22 elementy"},
+		{Locale: language.Polish, Count: 5, Expected: "5 elementów"},
+		{Locale: language.Polish, Count: 12, Expected: "12 elementów"},
+		{Locale: language.Polish, Count: 1.5, Expected: "1.5 elementu"},
+		{Locale: language.Arabic, Count: 0, Expected: "zero"},
+		{Locale: language.Arabic, Count: 2, Expected: "two"},
+		{Locale: language.Arabic, Count: 3, Expected: "few"},
+		{Locale: language.Arabic, Count: 11, Expected: "many"},
+		{Locale: language.Arabic, Count: 100, Expected: "other"},
+		{Locale: language.Dutch, Count: 1, Expected: "1 item"},
+	}
+
+	for _, test := range tests {
+		translated, ok := lt.Translate(test.Locale, validate.ErrorMap{
+			"A": validate.Errors{countError{Count: test.Count}},
+		})
+
+		c.Assert(ok, Equals, true)
+		c.Assert(translated, DeepEquals, map[string]string{"A": test.Expected}, Commentf("%s %v", test.Locale, test.Count))
+	}
+}
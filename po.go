@@ -0,0 +1,284 @@
+package errortranslator
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// POFallbackContext is the msgctxt used in gettext catalogs for the fallback translations, the empty field name.
+// The default translation of a field uses the DefaultCode as msgid.
+const POFallbackContext = "@fallback"
+
+// LoadPO reads a gettext PO catalog into a FieldErrorTranslator. The msgctxt holds the field name and the msgid the
+// error code, entries without msgctxt are fallback translations.
+// The header, fuzzy, obsolete and untranslated entries are skipped. Gettext plural forms (msgid_plural) are not
+// supported, use a plural placeholder in the msgstr instead.
+func LoadPO(fsys fs.FS, name string) (FieldErrorTranslator, error) {
+	return loadCatalog(fsys, name, parsePOCatalog)
+}
+
+// ExportPO writes the translator as gettext PO catalog for the language.
+// Fields and codes are sorted so the output is stable.
+func ExportPO(w io.Writer, ft FieldErrorTranslator, locale language.Tag) error {
+	return exportPO(w, ft, locale.String(), false)
+}
+
+// ExportPOT writes a gettext template (POT) with a entry for every translation in the translator.
+// The current translation is added as extracted comment so translators know what the message is about.
+func ExportPOT(w io.Writer, ft FieldErrorTranslator) error {
+	return exportPO(w, ft, "", true)
+}
+
+func exportPO(w io.Writer, ft FieldErrorTranslator, locale string, template bool) error {
+	catalog, err := ft.ByCode()
+	if err != nil {
+		return err
+	}
+
+	fields := make([]string, 0, len(catalog))
+	for field := range catalog {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "msgid \"\"\nmsgstr \"\"\n")
+	fmt.Fprintf(bw, "\"Language: %s\\n\"\n", locale)
+	fmt.Fprintf(bw, "\"MIME-Version: 1.0\\n\"\n")
+	fmt.Fprintf(bw, "\"Content-Type: text/plain; charset=UTF-8\\n\"\n")
+	fmt.Fprintf(bw, "\"Content-Transfer-Encoding: 8bit\\n\"\n")
+
+	for _, field := range fields {
+		codes := make([]string, 0, len(catalog[field]))
+		for code := range catalog[field] {
+			codes = append(codes, code)
+		}
+		sort.Strings(codes)
+
+		context := field
+		if field == "" {
+			context = POFallbackContext
+		}
+
+		for _, code := range codes {
+			message := catalog[field][code]
+
+			fmt.Fprintln(bw)
+			if template {
+				fmt.Fprintf(bw, "#. %s\n", strings.ReplaceAll(message, "\n", "\n#. "))
+				message = ""
+			}
+			fmt.Fprintf(bw, "msgctxt %s\n", quotePO(context))
+			fmt.Fprintf(bw, "msgid %s\n", quotePO(code))
+			fmt.Fprintf(bw, "msgstr %s\n", quotePO(message))
+		}
+	}
+	return bw.Flush()
+}
+
+func quotePO(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`, "\r", `\r`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// poEntry is a entry read from a PO file
+type poEntry struct {
+	context   *string
+	id        *string
+	str       *string
+	fuzzy     bool
+	idOffset  int
+	continued *string
+}
+
+func parsePOCatalog(b *catalogBuilder, data []byte) error {
+	var (
+		entry  poEntry
+		offset int
+	)
+
+	flush := func() error {
+		defer func() { entry = poEntry{} }()
+
+		//header, fuzzy and untranslated entries are skipped
+		if entry.id == nil || *entry.id == "" || entry.fuzzy || entry.str == nil || *entry.str == "" {
+			return nil
+		}
+
+		field := ""
+		if entry.context != nil && *entry.context != POFallbackContext {
+			field = *entry.context
+		}
+
+		if err := b.add(field, *entry.id, *entry.str); err != nil {
+			return b.errorAt(entry.idOffset, err)
+		}
+		return nil
+	}
+
+	for _, line := range strings.SplitAfter(string(data), "\n") {
+		lineOffset := offset
+		offset += len(line)
+
+		trimmed := strings.TrimSpace(line)
+		indent := strings.Index(line, trimmed)
+
+		var (
+			keyword, value string
+			target         **string
+		)
+
+		switch {
+		case trimmed == "":
+			if err := flush(); err != nil {
+				return err
+			}
+			continue
+		case strings.HasPrefix(trimmed, "#,"):
+			if entry.str != nil {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+			entry.fuzzy = entry.fuzzy || strings.Contains(trimmed, "fuzzy")
+			continue
+		case strings.HasPrefix(trimmed, "#"):
+			continue
+		case strings.HasPrefix(trimmed, `"`):
+			if entry.continued == nil {
+				return b.errorAt(lineOffset+indent, errors.New("string continuation without keyword"))
+			}
+
+			s, err := unquotePO(trimmed)
+			if err != nil {
+				return b.errorAt(lineOffset+indent, err)
+			}
+			*entry.continued += s
+			continue
+		case strings.HasPrefix(trimmed, "msgid_plural"), strings.HasPrefix(trimmed, "msgstr["):
+			return b.errorAt(lineOffset+indent, errors.New("gettext plural forms are not supported, use a plural placeholder"))
+		case strings.HasPrefix(trimmed, "msgctxt "):
+			keyword, target = "msgctxt", &entry.context
+		case strings.HasPrefix(trimmed, "msgid "):
+			keyword, target = "msgid", &entry.id
+		case strings.HasPrefix(trimmed, "msgstr "):
+			keyword, target = "msgstr", &entry.str
+		default:
+			return b.errorAt(lineOffset+indent, fmt.Errorf("unexpected line %q", trimmed))
+		}
+
+		//a new msgctxt or msgid starts the next entry
+		if keyword != "msgstr" && entry.str != nil {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+
+		if *target != nil {
+			return b.errorAt(lineOffset+indent, fmt.Errorf("duplicate %s", keyword))
+		}
+
+		value = strings.TrimSpace(trimmed[len(keyword):])
+		valueOffset := lineOffset + strings.Index(line, value)
+		s, err := unquotePO(value)
+		if err != nil {
+			return b.errorAt(valueOffset, err)
+		}
+
+		if keyword == "msgid" {
+			entry.idOffset = valueOffset
+		}
+		*target = &s
+		entry.continued = *target
+	}
+	return flush()
+}
+
+// unquotePO removes the quotes of a PO string and replaces the C escape sequences gettext supports: \a \b \f \n \r
+// \t \v \\ \" \' \?, octal (\101) and hexadecimal (\x41) escapes.
+func unquotePO(s string) (string, error) {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return "", errors.New("expected a quoted string")
+	}
+
+	var (
+		b      strings.Builder
+		quoted = s[1 : len(s)-1]
+	)
+	for i := 0; i < len(quoted); i++ {
+		ch := quoted[i]
+		if ch == '"' {
+			return "", fmt.Errorf("invalid quoted string %s: unescaped quote", s)
+		}
+
+		if ch != '\\' {
+			b.WriteByte(ch)
+			continue
+		}
+
+		if i++; i == len(quoted) {
+			return "", fmt.Errorf("invalid quoted string %s: escape at end of string", s)
+		}
+
+		switch ch = quoted[i]; ch {
+		case 'a':
+			b.WriteByte('\a')
+		case 'b':
+			b.WriteByte('\b')
+		case 'f':
+			b.WriteByte('\f')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case 'v':
+			b.WriteByte('\v')
+		case '\\', '"', '\'', '?':
+			b.WriteByte(ch)
+		case 'x':
+			n, digits := escapeValue(quoted[i+1:], 16, 2)
+			if digits == 0 {
+				return "", fmt.Errorf("invalid quoted string %s: invalid hexadecimal escape", s)
+			}
+			b.WriteByte(byte(n))
+			i += digits
+		case '0', '1', '2', '3', '4', '5', '6', '7':
+			n, digits := escapeValue(quoted[i:], 8, 3)
+			if n > 0xff {
+				return "", fmt.Errorf("invalid quoted string %s: octal escape out of range", s)
+			}
+			b.WriteByte(byte(n))
+			i += digits - 1
+		default:
+			return "", fmt.Errorf("invalid quoted string %s: unknown escape \\%c", s, ch)
+		}
+	}
+	return b.String(), nil
+}
+
+// escapeValue parses up to max digits of the base at the start of s, it returns the value and the number of digits.
+func escapeValue(s string, base int, max int) (int, int) {
+	if len(s) > max {
+		s = s[:max]
+	}
+	s = strings.ToLower(s)
+
+	n, digits := 0, 0
+	for ; digits < len(s); digits++ {
+		d := strings.IndexByte("0123456789abcdef"[:base], s[digits])
+		if d == -1 {
+			break
+		}
+		n = n*base + d
+	}
+	return n, digits
+}
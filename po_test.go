@@ -0,0 +1,186 @@
+package errortranslator_test
+
+import (
+	"bytes"
+	"testing/fstest"
+
+	errortranslator "github.com/mbict/go-errortranslator"
+	validate "github.com/mbict/go-validate"
+	"golang.org/x/text/language"
+	. "gopkg.in/check.v1"
+)
+
+type POSuite struct{}
+
+var _ = Suite(&POSuite{})
+
+var poTranslator = errortranslator.FieldErrorTranslator{
+	"A": errortranslator.ErrorTranslator{
+		validate.ErrRequired: "A is verplicht",
+		nil:                  "A is \"ongeldig\"\nprobeer opnieuw",
+	},
+	"": errortranslator.ErrorTranslator{
+		validate.ErrMin: "minimaal {min}",
+		nil:             "onbekende fout",
+	},
+}
+
+func (s *POSuite) TestExportPO(c *C) {
+	var buf bytes.Buffer
+	err := errortranslator.ExportPO(&buf, poTranslator, language.Dutch)
+
+	c.Assert(err, IsNil)
+	c.Assert(buf.String(), Equals, `msgid ""
+msgstr ""
+"Language: nl\n"
+"MIME-Version: 1.0\n"
+"Content-Type: text/plain; charset=UTF-8\n"
+"Content-Transfer-Encoding: 8bit\n"
+
+msgctxt "@fallback"
+msgid "default"
+msgstr "onbekende fout"
+
+msgctxt "@fallback"
+msgid "min"
+msgstr "minimaal {min}"
+
+msgctxt "A"
+msgid "default"
+msgstr "A is \"ongeldig\"\nprobeer opnieuw"
+
+msgctxt "A"
+msgid "required"
+msgstr "A is verplicht"
+`)
+}
+
+func (s *POSuite) TestExportPOT(c *C) {
+	var buf bytes.Buffer
+	err := errortranslator.ExportPOT(&buf, errortranslator.New().
+		AddTranslation("A", validate.ErrRequired, "A is required").
+		SetFallbackDefaultTranslation("unknown error"))
+
+	c.Assert(err, IsNil)
+	c.Assert(buf.String(), Equals, `msgid ""
+msgstr ""
+"Language: \n"
+"MIME-Version: 1.0\n"
+"Content-Type: text/plain; charset=UTF-8\n"
+"Content-Transfer-Encoding: 8bit\n"
+
+#. unknown error
+msgctxt "@fallback"
+msgid "default"
+msgstr ""
+
+#. A is required
+msgctxt "A"
+msgid "required"
+msgstr ""
+`)
+}
+
+func (s *POSuite) TestRoundTrip(c *C) {
+	var buf bytes.Buffer
+	c.Assert(errortranslator.ExportPO(&buf, poTranslator, language.Dutch), IsNil)
+
+	ft, err := errortranslator.LoadCatalog(fstest.MapFS{"nl.po": {Data: buf.Bytes()}}, "nl.po")
+
+	c.Assert(err, IsNil)
+	c.Assert(ft, DeepEquals, poTranslator)
+}
+
+func (s *POSuite) TestLoadPO(c *C) {
+	data := `# translator comment
+msgid ""
+msgstr ""
+"Language: nl\n"
+
+#: extracted
+msgctxt "A"
+msgid "required"
+msgstr ""
+"A is "
+"verplicht"
+msgctxt "A"
+msgid "min"
+msgstr ""
+
+#, fuzzy
+msgctxt "A"
+msgid "max"
+msgstr "A is te lang"
+
+#~ msgctxt "A"
+#~ msgid "default"
+#~ msgstr "obsolete"
+
+msgid "default"
+msgstr "onbekende fout"
+`
+	ft, err := errortranslator.LoadPO(fstest.MapFS{"nl.po": {Data: []byte(data)}}, "nl.po")
+
+	c.Assert(err, IsNil)
+	c.Assert(ft, DeepEquals, errortranslator.FieldErrorTranslator{
+		"A": errortranslator.ErrorTranslator{
+			validate.ErrRequired: "A is verplicht",
+		},
+		"": errortranslator.ErrorTranslator{
+			nil: "onbekende fout",
+		},
+	})
+}
+
+func (s *POSuite) TestLoadPOEscapes(c *C) {
+	data := `msgctxt "A"
+msgid "required"
+msgstr "it\'s \"required\"\?\n\ttab \\ \101\x42\0"
+`
+	ft, err := errortranslator.LoadPO(fstest.MapFS{"nl.po": {Data: []byte(data)}}, "nl.po")
+
+	c.Assert(err, IsNil)
+	c.Assert(ft["A"][validate.ErrRequired], Equals, "it's \"required\"?\n\ttab \\ AB\x00")
+}
+
+func (s *POSuite) TestLoadPOErrors(c *C) {
+	tests := []struct {
+		Data     string
+		Expected string
+	}{
+		{
+			Data:     "msgctxt \"A\"\nmsgid \"requird\"\nmsgstr \"A is verplicht\"\n",
+			Expected: `nl.po:2:7: unknown error code "requird" for field "A"`,
+		}, {
+			Data:     "msgctxt \"A\"\nmsgid \"required\"\nmsgstr \"A is {verplicht\"\n",
+			Expected: `nl.po:2:7: errortranslator: malformed message .*`,
+		}, {
+			Data:     "msgctxt \"A\"\nmsgid \"required\"\nmsgid_plural \"required\"\nmsgstr[0] \"A\"\n",
+			Expected: `nl.po:3:1: gettext plural forms are not supported, use a plural placeholder`,
+		}, {
+			Data:     "msgctxt \"A\"\nmsgid required\n",
+			Expected: `nl.po:2:7: expected a quoted string`,
+		}, {
+			Data:     "msgctxt \"A\"\nmsgid \"required\"\nmsgstr \"A \\z\"\n",
+			Expected: `nl.po:3:8: invalid quoted string "A \\z": unknown escape \\z`,
+		}, {
+			Data:     "msgctxt \"A\"\nmsgid \"required\"\nmsgstr \"A \" B\"\n",
+			Expected: `nl.po:3:8: invalid quoted string .*: unescaped quote`,
+		}, {
+			Data:     "msgctxt \"A\"\n  \"continued\"\nmsgid \"required\"\nmsgstr \"x\"\nmsgstr \"y\"\n",
+			Expected: `nl.po:5:1: duplicate msgstr`,
+		}, {
+			Data:     "\"orphan\"\n",
+			Expected: `nl.po:1:1: string continuation without keyword`,
+		}, {
+			Data:     "msgctxt \"A\"\nmsgid \"required\"\nmsgstr \"x\"\nunknown\n",
+			Expected: `nl.po:4:1: unexpected line "unknown"`,
+		},
+	}
+
+	for _, test := range tests {
+		_, err := errortranslator.LoadPO(fstest.MapFS{"nl.po": {Data: []byte(test.Data)}}, "nl.po")
+
+		c.Assert(err, ErrorMatches, test.Expected, Commentf(test.Data))
+	}
+}
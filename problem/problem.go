@@ -0,0 +1,168 @@
+// Package problem renders translated validation errors as a problem details response (RFC 9457, previously RFC 7807).
+//
+// The errors of a validate.ErrorMap are listed in the `errors` extension member, one entry per translated error with a
+// JSON Pointer to the field, the error code and the translated message:
+//
+//	{
+//	  "title": "Unprocessable Entity",
+//	  "status": 422,
+//	  "errors": [
+//	    {"pointer": "/address/street", "code": "required", "detail": "The street is required"}
+//	  ]
+//	}
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+
+	errortranslator "github.com/mbict/go-errortranslator"
+	validate "github.com/mbict/go-validate"
+)
+
+// ContentType is the media type of a problem details response
+const ContentType = "application/problem+json"
+
+// Problem is a problem details object with the validation errors as extension member.
+type Problem struct {
+	Type     string  `json:"type,omitempty"`
+	Title    string  `json:"title,omitempty"`
+	Status   int     `json:"status,omitempty"`
+	Detail   string  `json:"detail,omitempty"`
+	Instance string  `json:"instance,omitempty"`
+	Errors   []Error `json:"errors"`
+}
+
+// Error is a single validation error of a field.
+type Error struct {
+	// Pointer is the JSON Pointer to the field in the request document.
+	Pointer string `json:"pointer"`
+
+	// Code is the error code (see errortranslator.CodeForError), empty when the error is not registered.
+	Code string `json:"code,omitempty"`
+
+	// Type is the type URI configured for the error code.
+	Type string `json:"type,omitempty"`
+
+	// Detail is the translated message, empty when the error could not be translated.
+	Detail string `json:"detail,omitempty"`
+}
+
+// Renderer renders error maps as problem details.
+type Renderer struct {
+	Translator errortranslator.DetailTranslator
+
+	// Type is the problem type URI, when empty the type is omitted which means "about:blank".
+	Type string
+
+	// Title is the title of the problem, the status text of the status is used when empty.
+	Title string
+
+	// Status is the HTTP status code, http.StatusUnprocessableEntity is used when zero.
+	Status int
+
+	// Detail is a optional human readable explanation of the problem.
+	Detail string
+
+	// TypeURIs maps error codes to the URI that documents the error, e.g. "required": "https://example.com/required".
+	TypeURIs map[string]string
+
+	// Fallback translations passed to the translator.
+	Fallback []errortranslator.ErrorTranslator
+}
+
+// New creates a new Renderer that translates with the translator.
+func New(translator errortranslator.DetailTranslator) *Renderer {
+	return &Renderer{
+		Translator: translator,
+	}
+}
+
+// Problem translates the error map into a problem details object.
+// The errors are sorted by pointer and keep the order of the error map within a field. Errors without translation are
+// listed with their error code and without detail, see errortranslator.WithUntranslated.
+func (r *Renderer) Problem(errorMap validate.ErrorMap) *Problem {
+	status := r.Status
+	if status == 0 {
+		status = http.StatusUnprocessableEntity
+	}
+
+	title := r.Title
+	if title == "" {
+		title = http.StatusText(status)
+	}
+
+	translations, _ := r.Translator.TranslateDetails(errorMap, r.Fallback...)
+	translations = errortranslator.WithUntranslated(errorMap, translations)
+
+	fields := make([]string, 0, len(errorMap))
+	for field := range errorMap {
+		fields = append(fields, field)
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return Pointer(fields[i]) < Pointer(fields[j])
+	})
+
+	errs := []Error{}
+	for _, field := range fields {
+		for _, translation := range translations[field] {
+			errs = append(errs, r.newError(Pointer(field), translation.Code, translation.Message))
+		}
+	}
+
+	return &Problem{
+		Type:   r.Type,
+		Title:  title,
+		Status: status,
+		Detail: r.Detail,
+		Errors: errs,
+	}
+}
+
+func (r *Renderer) newError(pointer string, code string, detail string) Error {
+	return Error{
+		Pointer: pointer,
+		Code:    code,
+		Type:    r.TypeURIs[code],
+		Detail:  detail,
+	}
+}
+
+// Write writes the error map as problem details response with the status of the problem.
+func (r *Renderer) Write(w http.ResponseWriter, errorMap validate.ErrorMap) error {
+	return r.Problem(errorMap).Write(w)
+}
+
+// Write writes the problem as response with the status of the problem.
+func (p *Problem) Write(w http.ResponseWriter) error {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(p.Status)
+	_, err = w.Write(data)
+	return err
+}
+
+// Pointer converts a field name into a JSON Pointer (RFC 6901). Dots and brackets separate the reference tokens:
+// `address.street` becomes `/address/street` and `items[0].price` becomes `/items/0/price`.
+// The empty field name points to the whole document.
+func Pointer(field string) string {
+	if field == "" {
+		return ""
+	}
+
+	escaper := strings.NewReplacer("~", "~0", "/", "~1")
+	tokens := strings.Split(strings.NewReplacer("[", ".", "]", "").Replace(field), ".")
+
+	var b strings.Builder
+	for _, token := range tokens {
+		b.WriteByte('/')
+		b.WriteString(escaper.Replace(token))
+	}
+	return b.String()
+}
@@ -0,0 +1,95 @@
+package problem_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	errortranslator "github.com/mbict/go-errortranslator"
+	"github.com/mbict/go-errortranslator/problem"
+	validate "github.com/mbict/go-validate"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) {
+	TestingT(t)
+}
+
+type ProblemSuite struct{}
+
+var _ = Suite(&ProblemSuite{})
+
+var translations = errortranslator.New().
+	AddTranslation("address.street", validate.ErrRequired, "The street is required").
+	AddTranslation("items[*].price", validate.ErrMin, "The price is too low").
+	SetFallbackTranslation(validate.ErrMax, "The {field} is too long")
+
+func (s *ProblemSuite) TestPointer(c *C) {
+	tests := []struct {
+		Field    string
+		Expected string
+	}{
+		{Field: "", Expected: ""},
+		{Field: "name", Expected: "/name"},
+		{Field: "address.street", Expected: "/address/street"},
+		{Field: "items[0].price", Expected: "/items/0/price"},
+		{Field: "matrix[1][2]", Expected: "/matrix/1/2"},
+		{Field: "a/b~c", Expected: "/a~1b~0c"},
+	}
+
+	for _, test := range tests {
+		c.Assert(problem.Pointer(test.Field), Equals, test.Expected, Commentf(test.Field))
+	}
+}
+
+func (s *ProblemSuite) TestProblem(c *C) {
+	r := problem.New(translations)
+	r.TypeURIs = map[string]string{"required": "https://example.com/errors/required"}
+
+	p := r.Problem(validate.ErrorMap{
+		"items[0].price": validate.Errors{validate.ErrMin, validate.ErrMax},
+		"address.street": validate.Errors{validate.ErrRequired},
+		"address.city":   validate.Errors{validate.ErrMin, validate.ErrRequired, validate.ErrMax},
+		"name":           validate.Errors{validate.ErrMin},
+	})
+
+	c.Assert(p, DeepEquals, &problem.Problem{
+		Title:  "Unprocessable Entity",
+		Status: http.StatusUnprocessableEntity,
+		Errors: []problem.Error{
+			{Pointer: "/address/city", Code: "min"},
+			{Pointer: "/address/city", Code: "required", Type: "https://example.com/errors/required"},
+			{Pointer: "/address/city", Code: "max", Detail: "The address.city is too long"},
+			{Pointer: "/address/street", Code: "required", Type: "https://example.com/errors/required", Detail: "The street is required"},
+			{Pointer: "/items/0/price", Code: "min", Detail: "The price is too low"},
+			{Pointer: "/items/0/price", Code: "max", Detail: "The items[0].price is too long"},
+			{Pointer: "/name", Code: "min"},
+		},
+	})
+}
+
+func (s *ProblemSuite) TestWrite(c *C) {
+	r := problem.New(translations.WithOptions(errortranslator.Options{}))
+	r.Type = "https://example.com/problems/validation"
+	r.Title = "Your request is not valid"
+	r.Status = http.StatusBadRequest
+
+	w := httptest.NewRecorder()
+	err := r.Write(w, validate.ErrorMap{
+		"address.street": validate.Errors{validate.ErrRequired},
+	})
+
+	c.Assert(err, IsNil)
+	c.Assert(w.Code, Equals, http.StatusBadRequest)
+	c.Assert(w.Header().Get("Content-Type"), Equals, problem.ContentType)
+	c.Assert(w.Body.String(), Equals, `{"type":"https://example.com/problems/validation","title":"Your request is not valid",`+
+		`"status":400,"errors":[{"pointer":"/address/street","code":"required","detail":"The street is required"}]}`)
+}
+
+func (s *ProblemSuite) TestEmptyErrorMap(c *C) {
+	w := httptest.NewRecorder()
+	err := problem.New(translations).Write(w, validate.ErrorMap{})
+
+	c.Assert(err, IsNil)
+	c.Assert(w.Body.String(), Equals, `{"title":"Unprocessable Entity","status":422,"errors":[]}`)
+}
@@ -0,0 +1,243 @@
+// Package reload loads a directory with translation catalogs and reloads it when the files change.
+//
+// Every catalog file in the directory holds the translations of a language, the file name without extension is the
+// language tag: `en.json`, `nl.yaml` and `nl-BE.po`. The formats of errortranslator.LoadCatalog are supported, files
+// with other extensions are ignored.
+//
+// A reload reads and validates all the catalogs before the new translations are used, when a catalog is invalid the
+// previous translations stay in use. Changes are detected with file system notifications, polling is used when
+// notifications are not available.
+//
+//	catalog, err := reload.Load("translations", reload.Options{DefaultLocale: language.English})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	go catalog.Watch(ctx)
+//
+//	translated, ok := catalog.Translator().TranslateAcceptLanguage(r.Header.Get("Accept-Language"), errorMap)
+package reload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	errortranslator "github.com/mbict/go-errortranslator"
+	"golang.org/x/text/language"
+)
+
+// ErrNoCatalogs is returned when the directory does not contain any catalog file
+var ErrNoCatalogs = errors.New("reload: no catalogs found")
+
+const (
+	defaultPollInterval = 2 * time.Second
+	defaultDebounce     = 100 * time.Millisecond
+)
+
+// Options configures the loading and watching of a catalog directory.
+type Options struct {
+	// DefaultLocale is the default language of the LocaleTranslator, see errortranslator.NewLocaleTranslator
+	DefaultLocale language.Tag
+
+	// Translator are the options of the LocaleTranslator, see errortranslator.Options
+	Translator errortranslator.Options
+
+	// Poll forces polling for changes instead of file system notifications.
+	Poll bool
+
+	// PollInterval is the time between two checks for changes when polling, the default is 2 seconds.
+	PollInterval time.Duration
+
+	// Debounce is the time to wait after a notification for more changes before reloading, the default is 100ms.
+	Debounce time.Duration
+
+	// OnReload is called after every reload triggered by a change.
+	OnReload func(Event)
+}
+
+// Event describes a reload
+type Event struct {
+	// Locales are the languages loaded, the languages of the previous translations when the reload failed.
+	Locales []language.Tag
+
+	// Err is the error of a failed reload, the previous translations are still in use.
+	Err error
+}
+
+// Catalog holds the translations of a catalog directory
+type Catalog struct {
+	dir        string
+	opts       Options
+	translator atomic.Pointer[errortranslator.LocaleTranslator]
+	mu         sync.Mutex
+}
+
+// Load loads all the catalogs in the directory.
+func Load(dir string, opts Options) (*Catalog, error) {
+	c := &Catalog{
+		dir:  dir,
+		opts: opts,
+	}
+
+	if err := c.Reload(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Translator returns the current translations. The returned translator must not be modified, it is shared with
+// the other goroutines. Get the translator for every translation to use the latest translations.
+func (c *Catalog) Translator() *errortranslator.LocaleTranslator {
+	return c.translator.Load()
+}
+
+// Reload loads all the catalogs in the directory and replaces the current translations when all catalogs are valid.
+func (c *Catalog) Reload() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	lt, err := loadDir(c.dir, c.opts)
+	if err != nil {
+		return err
+	}
+	c.translator.Store(lt)
+	return nil
+}
+
+// reload reloads the catalogs and reports the result to the OnReload callback
+func (c *Catalog) reload() {
+	err := c.Reload()
+	if c.opts.OnReload != nil {
+		c.opts.OnReload(Event{Locales: c.Translator().Locales(), Err: err})
+	}
+}
+
+// Watch reloads the catalogs when the files in the directory change, it blocks until the context is done.
+// File system notifications are used when available, otherwise the directory is polled for changes.
+func (c *Catalog) Watch(ctx context.Context) error {
+	if !c.opts.Poll {
+		watcher, err := fsnotify.NewWatcher()
+		if err == nil {
+			defer watcher.Close()
+			if err = watcher.Add(c.dir); err == nil {
+				return c.notify(ctx, watcher)
+			}
+		}
+	}
+	return c.poll(ctx)
+}
+
+// notify reloads on file system notifications, changes within the debounce time result in a single reload
+func (c *Catalog) notify(ctx context.Context, watcher *fsnotify.Watcher) error {
+	debounce := c.opts.Debounce
+	if debounce <= 0 {
+		debounce = defaultDebounce
+	}
+
+	timer := time.NewTimer(debounce)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Has(fsnotify.Chmod) && !event.Has(fsnotify.Write) {
+				continue
+			}
+			timer.Reset(debounce)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			if c.opts.OnReload != nil {
+				c.opts.OnReload(Event{Locales: c.Translator().Locales(), Err: err})
+			}
+		case <-timer.C:
+			c.reload()
+		}
+	}
+}
+
+// poll compares the modification times and sizes of the files every interval and reloads on a change
+func (c *Catalog) poll(ctx context.Context) error {
+	interval := c.opts.PollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	state := dirState(c.dir)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if current := dirState(c.dir); current != state {
+				state = current
+				c.reload()
+			}
+		}
+	}
+}
+
+// dirState returns a description of the catalog files in the directory that changes when a file changes
+func dirState(dir string) string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "error: " + err.Error()
+	}
+
+	var b strings.Builder
+	for _, entry := range entries {
+		if !errortranslator.IsCatalogFile(entry.Name()) {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(&b, "%s %d %d\n", entry.Name(), info.Size(), info.ModTime().UnixNano())
+	}
+	return b.String()
+}
+
+// loadDir loads the catalogs of the directory into a new LocaleTranslator, the languages are added in sorted order
+func loadDir(dir string, opts Options) (*errortranslator.LocaleTranslator, error) {
+	catalogs, err := errortranslator.LoadCatalogDir(os.DirFS(dir), ".")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(catalogs) == 0 {
+		return nil, ErrNoCatalogs
+	}
+
+	locales := make([]language.Tag, 0, len(catalogs))
+	for locale := range catalogs {
+		locales = append(locales, locale)
+	}
+	sort.Slice(locales, func(i, j int) bool {
+		return locales[i].String() < locales[j].String()
+	})
+
+	lt := errortranslator.NewLocaleTranslator(opts.DefaultLocale).SetOptions(opts.Translator)
+	for _, locale := range locales {
+		lt.AddLocale(locale, catalogs[locale])
+	}
+	return lt, nil
+}
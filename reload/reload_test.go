@@ -0,0 +1,142 @@
+package reload_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	errortranslator "github.com/mbict/go-errortranslator"
+	"github.com/mbict/go-errortranslator/reload"
+	validate "github.com/mbict/go-validate"
+	"golang.org/x/text/language"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) {
+	TestingT(t)
+}
+
+type ReloadSuite struct{}
+
+var _ = Suite(&ReloadSuite{})
+
+var errorMap = validate.ErrorMap{"name": validate.Errors{validate.ErrRequired}}
+
+func writeFile(c *C, dir string, name string, content string) {
+	c.Assert(os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644), IsNil)
+}
+
+func translate(catalog *reload.Catalog, locale language.Tag) string {
+	result, _ := catalog.Translator().Translate(locale, errorMap)
+	return result["name"]
+}
+
+func (s *ReloadSuite) TestLoad(c *C) {
+	dir := c.MkDir()
+	writeFile(c, dir, "en.json", `{"name": {"required": "The name is required"}}`)
+	writeFile(c, dir, "nl-BE.yaml", "name:\n  required: De naam is verplicht\n")
+	writeFile(c, dir, "README.md", "not a catalog")
+
+	catalog, err := reload.Load(dir, reload.Options{DefaultLocale: language.English})
+
+	c.Assert(err, IsNil)
+	c.Assert(catalog.Translator().Locales(), HasLen, 2)
+	c.Assert(translate(catalog, language.English), Equals, "The name is required")
+	c.Assert(translate(catalog, language.MustParse("nl-BE")), Equals, "De naam is verplicht")
+}
+
+func (s *ReloadSuite) TestLoadErrors(c *C) {
+	dir := c.MkDir()
+	_, err := reload.Load(dir, reload.Options{DefaultLocale: language.English})
+	c.Assert(err, Equals, reload.ErrNoCatalogs)
+
+	writeFile(c, dir, "english.json", `{}`)
+	_, err = reload.Load(dir, reload.Options{DefaultLocale: language.English})
+	c.Assert(err, ErrorMatches, "english.json: invalid language in file name: .*")
+
+	c.Assert(os.Remove(filepath.Join(dir, "english.json")), IsNil)
+	writeFile(c, dir, "en.json", `{}`)
+	writeFile(c, dir, "en.yaml", `{}`)
+	_, err = reload.Load(dir, reload.Options{DefaultLocale: language.English})
+	c.Assert(err, ErrorMatches, "en.yaml: language en is already loaded from en.json")
+
+	c.Assert(os.Remove(filepath.Join(dir, "en.yaml")), IsNil)
+	writeFile(c, dir, "en.json", `{"name": {"unknown": "x"}}`)
+	_, err = reload.Load(dir, reload.Options{DefaultLocale: language.English})
+	c.Assert(errors.Is(err, errortranslator.ErrUnknownErrorCode), Equals, true)
+}
+
+// watch starts watching the catalog and returns a channel with the reload events
+func watch(c *C, opts reload.Options) (*reload.Catalog, string, <-chan reload.Event, func()) {
+	dir := c.MkDir()
+	writeFile(c, dir, "en.json", `{"name": {"required": "The name is required"}}`)
+
+	events := make(chan reload.Event, 10)
+	opts.DefaultLocale = language.English
+	opts.OnReload = func(event reload.Event) {
+		events <- event
+	}
+
+	catalog, err := reload.Load(dir, opts)
+	c.Assert(err, IsNil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- catalog.Watch(ctx)
+	}()
+
+	return catalog, dir, events, func() {
+		cancel()
+		c.Assert(<-done, Equals, context.Canceled)
+	}
+}
+
+func waitForEvent(c *C, events <-chan reload.Event) reload.Event {
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(5 * time.Second):
+		c.Fatal("no reload event")
+	}
+	return reload.Event{}
+}
+
+func (s *ReloadSuite) testWatch(c *C, opts reload.Options) {
+	catalog, dir, events, stop := watch(c, opts)
+	defer stop()
+
+	//allow the watcher to start
+	time.Sleep(50 * time.Millisecond)
+
+	writeFile(c, dir, "en.json", `{"name": {"required": "Please enter a name"}}`)
+	event := waitForEvent(c, events)
+	c.Assert(event.Err, IsNil)
+	c.Assert(event.Locales, DeepEquals, []language.Tag{language.English})
+	c.Assert(translate(catalog, language.English), Equals, "Please enter a name")
+
+	//a invalid catalog keeps the previous translations
+	writeFile(c, dir, "en.json", `{"name": {"required": "Please enter a {name"}}`)
+	event = waitForEvent(c, events)
+	c.Assert(event.Err, NotNil)
+	c.Assert(translate(catalog, language.English), Equals, "Please enter a name")
+
+	//a new language
+	writeFile(c, dir, "en.json", `{"name": {"required": "Please enter a name"}}`)
+	writeFile(c, dir, "nl.json", `{"name": {"required": "Vul een naam in"}}`)
+	for event = waitForEvent(c, events); len(event.Locales) != 2; event = waitForEvent(c, events) {
+	}
+	c.Assert(event.Err, IsNil)
+	c.Assert(translate(catalog, language.Dutch), Equals, "Vul een naam in")
+}
+
+func (s *ReloadSuite) TestWatchNotify(c *C) {
+	s.testWatch(c, reload.Options{Debounce: 20 * time.Millisecond})
+}
+
+func (s *ReloadSuite) TestWatchPoll(c *C) {
+	s.testWatch(c, reload.Options{Poll: true, PollInterval: 20 * time.Millisecond})
+}
@@ -0,0 +1,117 @@
+package errortranslator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// MessageTag is the struct tag that holds the translations of a field
+const MessageTag = "errmsg"
+
+// FromStruct creates a FieldErrorTranslator from the `errmsg` tags of a struct. A tag holds a list of error codes
+// (see ErrorForCode) with their message separated by semicolons. A semicolon in a message is escaped with a backslash,
+// which is written as `\\;` in the tag because tag values are quoted strings:
+//
+//	type User struct {
+//		Email string `validate:"required" errmsg:"required=Please enter your email;default=Email is invalid"`
+//	}
+//
+// The field keys are the paths of the fields like the validator reports them, see FieldOrder. Fields of the elements
+// of slices, arrays and maps get a pattern as key, `Items.*.Price`.
+// v is a struct, a pointer to a struct or a reflect.Type of one of these. Unknown error codes, duplicate codes and
+// malformed messages are returned as error.
+func FromStruct(v interface{}) (FieldErrorTranslator, error) {
+	t, ok := v.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(v)
+	}
+
+	t = indirectType(t)
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("errortranslator: %v is not a struct", t)
+	}
+
+	var (
+		ft  = New()
+		err error
+	)
+	walkStructFields(t, "", make(map[reflect.Type]bool), func(path string, f reflect.StructField) {
+		tag, ok := f.Tag.Lookup(MessageTag)
+		if !ok || err != nil {
+			return
+		}
+
+		if terr := addTagTranslations(ft, path, tag); terr != nil {
+			err = fmt.Errorf("errortranslator: %s field %s: %w", t, path, terr)
+		}
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return ft, nil
+}
+
+// MustFromStruct works the same as FromStruct but panics on a error, useful to initialize package variables.
+func MustFromStruct(v interface{}) FieldErrorTranslator {
+	ft, err := FromStruct(v)
+	if err != nil {
+		panic(err)
+	}
+	return ft
+}
+
+func addTagTranslations(ft FieldErrorTranslator, field string, tag string) error {
+	if _, ok := ft[field]; !ok {
+		ft[field] = ErrorTranslator{}
+	}
+
+	for _, entry := range splitTag(tag) {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+
+		code, message, ok := strings.Cut(entry, "=")
+		if !ok {
+			return fmt.Errorf("expected code=message in %q", entry)
+		}
+
+		code = strings.TrimSpace(code)
+		err, ok := ErrorForCode(code)
+		if !ok {
+			return fmt.Errorf("%w %q", ErrUnknownErrorCode, code)
+		}
+
+		if _, ok := ft[field][err]; ok {
+			return fmt.Errorf("duplicate translation for error code %q", code)
+		}
+
+		if merr := ValidateMessage(message); merr != nil {
+			return merr
+		}
+		ft[field][err] = message
+	}
+	return nil
+}
+
+// splitTag splits the tag in entries at the semicolons, a escaped semicolon `\;` is part of the entry.
+func splitTag(tag string) []string {
+	var (
+		entries []string
+		entry   strings.Builder
+	)
+	for i := 0; i < len(tag); i++ {
+		switch {
+		case tag[i] == '\\' && i+1 < len(tag) && tag[i+1] == ';':
+			entry.WriteByte(';')
+			i++
+		case tag[i] == ';':
+			entries = append(entries, entry.String())
+			entry.Reset()
+		default:
+			entry.WriteByte(tag[i])
+		}
+	}
+	return append(entries, entry.String())
+}
@@ -0,0 +1,109 @@
+package errortranslator_test
+
+import (
+	"errors"
+
+	errortranslator "github.com/mbict/go-errortranslator"
+	validate "github.com/mbict/go-validate"
+	. "gopkg.in/check.v1"
+)
+
+type StructTagSuite struct{}
+
+var _ = Suite(&StructTagSuite{})
+
+type tagBase struct {
+	ID int `errmsg:"required=The id is required"`
+}
+
+type tagLine struct {
+	Price int `errmsg:"min=The price must be at least {min}"`
+}
+
+type tagUser struct {
+	tagBase
+	Email   string `validate:"required" errmsg:"required=Please enter your email;default=Email is invalid"`
+	Name    string `errmsg:"max=Use at most 50 characters\\; shorten your name"`
+	Skipped string
+	Address *struct {
+		Street string `errmsg:"required=The street is required"`
+	}
+	Lines []tagLine `errmsg:"min=Add at least one line"`
+}
+
+func (s *StructTagSuite) TestFromStruct(c *C) {
+	ft, err := errortranslator.FromStruct(&tagUser{})
+
+	c.Assert(err, IsNil)
+	c.Assert(ft, DeepEquals, errortranslator.FieldErrorTranslator{
+		"ID": errortranslator.ErrorTranslator{
+			validate.ErrRequired: "The id is required",
+		},
+		"Email": errortranslator.ErrorTranslator{
+			validate.ErrRequired: "Please enter your email",
+			nil:                  "Email is invalid",
+		},
+		"Name": errortranslator.ErrorTranslator{
+			validate.ErrMax: "Use at most 50 characters; shorten your name",
+		},
+		"Address.Street": errortranslator.ErrorTranslator{
+			validate.ErrRequired: "The street is required",
+		},
+		"Lines": errortranslator.ErrorTranslator{
+			validate.ErrMin: "Add at least one line",
+		},
+		"Lines.*.Price": errortranslator.ErrorTranslator{
+			validate.ErrMin: "The price must be at least {min}",
+		},
+	})
+
+	result, ok := ft.Translate(validate.ErrorMap{
+		"Email":          validate.Errors{validate.ErrRequired, validate.ErrMin},
+		"Address.Street": validate.Errors{validate.ErrRequired},
+		"Lines.1.Price":  validate.Errors{validate.ErrMin},
+	})
+
+	c.Assert(ok, Equals, true)
+	c.Assert(result, DeepEquals, map[string]string{
+		"Email":          "Please enter your email, Email is invalid",
+		"Address.Street": "The street is required",
+		"Lines.1.Price":  "The price must be at least {min}",
+	})
+}
+
+func (s *StructTagSuite) TestFromStructErrors(c *C) {
+	_, err := errortranslator.FromStruct(struct {
+		Email string `errmsg:"unknown=x"`
+	}{})
+	c.Assert(err, ErrorMatches, `errortranslator: struct .* field Email: unknown error code "unknown"`)
+	c.Assert(errors.Is(err, errortranslator.ErrUnknownErrorCode), Equals, true)
+
+	_, err = errortranslator.FromStruct(struct {
+		Email string `errmsg:"required=a;required=b"`
+	}{})
+	c.Assert(err, ErrorMatches, `.* field Email: duplicate translation for error code "required"`)
+
+	_, err = errortranslator.FromStruct(struct {
+		Email string `errmsg:"required"`
+	}{})
+	c.Assert(err, ErrorMatches, `.* field Email: expected code=message in "required"`)
+
+	_, err = errortranslator.FromStruct(struct {
+		Lines []struct {
+			Price int `errmsg:"min=at least {min"`
+		}
+	}{})
+	c.Assert(err, ErrorMatches, `.* field Lines.\*.Price: errortranslator: malformed message .*`)
+
+	var merr *errortranslator.MessageError
+	c.Assert(errors.As(err, &merr), Equals, true)
+
+	_, err = errortranslator.FromStruct("not a struct")
+	c.Assert(err, ErrorMatches, "errortranslator: string is not a struct")
+
+	c.Assert(func() {
+		errortranslator.MustFromStruct(struct {
+			Email string `errmsg:"unknown=x"`
+		}{})
+	}, PanicMatches, `errortranslator: .* unknown error code "unknown"`)
+}
@@ -0,0 +1,76 @@
+package errortranslator
+
+import (
+	"reflect"
+
+	validate "github.com/mbict/go-validate"
+)
+
+// Translation is the translation of a single error.
+type Translation struct {
+	// Code is the error code of the matched translation (see CodeForError), DefaultCode for a default translation.
+	// The code is empty when the matched error is not registered.
+	Code string
+
+	// Message is the translated message with the placeholders replaced.
+	Message string
+
+	// MatchedKey is the field key that provided the translation, a exact field name or a pattern (see Match).
+	// It is empty when the translation is provided by the fallback translations.
+	MatchedKey string
+
+	// UsedFallback reports if the translation is provided by the fallback translations, the translations of the empty
+	// field or the fallback translators passed to Translate.
+	UsedFallback bool
+
+	// Err is the original error that is translated.
+	Err error
+}
+
+// DetailTranslator translates a error map into a list of translations per field.
+// FieldErrorTranslator, *Translator, *SyncTranslator and *LocalizedTranslator implement this interface.
+type DetailTranslator interface {
+	TranslateDetails(errorMap validate.ErrorMap, fallback ...ErrorTranslator) (map[string][]Translation, bool)
+}
+
+func newTranslation(key error, message string, err error) Translation {
+	code, _ := codeForKey(key)
+	return Translation{
+		Code:    code,
+		Message: message,
+		Err:     err,
+	}
+}
+
+// WithUntranslated returns the translations of TranslateDetails completed with a translation for every error of the
+// error map that is not translated, to list all the errors. The translations of a field are in the order of the
+// errors, a untranslated error has the code of the error (see CodeForError) and no message. Errors left out by the
+// Dedupe and SuppressDefault options are added without message as well.
+func WithUntranslated(errorMap validate.ErrorMap, translations map[string][]Translation) map[string][]Translation {
+	result := make(map[string][]Translation, len(errorMap))
+	for field, errs := range errorMap {
+		//the translations are in the order of the errors, errors without translation are skipped
+		translated := translations[field]
+		complete := make([]Translation, 0, len(errs))
+		for _, err := range errs {
+			if len(translated) > 0 && sameError(translated[0].Err, err) {
+				complete = append(complete, translated[0])
+				translated = translated[1:]
+				continue
+			}
+
+			code, _ := CodeForError(err)
+			complete = append(complete, Translation{Code: code, Err: err})
+		}
+		result[field] = complete
+	}
+	return result
+}
+
+// sameError reports if both errors are the same value, errors that cannot be compared are compared deeply.
+func sameError(a error, b error) bool {
+	if !isComparable(a) || !isComparable(b) {
+		return reflect.DeepEqual(a, b)
+	}
+	return a == b
+}
@@ -0,0 +1,172 @@
+package errortranslator_test
+
+import (
+	"errors"
+	"fmt"
+
+	errortranslator "github.com/mbict/go-errortranslator"
+	validate "github.com/mbict/go-validate"
+	"golang.org/x/text/language"
+	. "gopkg.in/check.v1"
+)
+
+type TranslationSuite struct{}
+
+var _ = Suite(&TranslationSuite{})
+
+var errUnregistered = errors.New("unregistered")
+
+var detailTranslations = errortranslator.FieldErrorTranslator{
+	"A": errortranslator.ErrorTranslator{
+		validate.ErrRequired: "A required translate",
+		nil:                  "A default translate",
+	},
+	"B.*": errortranslator.ErrorTranslator{
+		validate.ErrMin: "B min translate",
+		errUnregistered: "B unregistered translate",
+	},
+	"": errortranslator.ErrorTranslator{
+		validate.ErrMax: "nil max default translate",
+	},
+}
+
+func (s *TranslationSuite) TestTranslateDetails(c *C) {
+	wrapped := fmt.Errorf("wrapped: %w", validate.ErrMin)
+	translations, ok := detailTranslations.TranslateDetails(validate.ErrorMap{
+		"A":   validate.Errors{validate.ErrRequired, validate.ErrMin},
+		"B.1": validate.Errors{wrapped, validate.ErrMax, errUnregistered},
+		"C":   validate.Errors{validate.ErrRequired},
+	}, errortranslator.ErrorTranslator{
+		validate.ErrRequired: "caller required translate",
+	})
+
+	c.Assert(ok, Equals, true)
+	c.Assert(translations, DeepEquals, map[string][]errortranslator.Translation{
+		"A": {
+			{Code: "required", Message: "A required translate", MatchedKey: "A", Err: validate.ErrRequired},
+			{Code: "default", Message: "A default translate", MatchedKey: "A", Err: validate.ErrMin},
+		},
+		"B.1": {
+			{Code: "min", Message: "B min translate", MatchedKey: "B.*", Err: wrapped},
+			{Code: "max", Message: "nil max default translate", UsedFallback: true, Err: validate.ErrMax},
+			{Code: "", Message: "B unregistered translate", MatchedKey: "B.*", Err: errUnregistered},
+		},
+		"C": {
+			{Code: "required", Message: "caller required translate", UsedFallback: true, Err: validate.ErrRequired},
+		},
+	})
+}
+
+func (s *TranslationSuite) TestTranslateFirstDetails(c *C) {
+	translations, ok := detailTranslations.TranslateFirstDetails(validate.ErrorMap{
+		"B.1": validate.Errors{validate.ErrRequired, validate.ErrMax, validate.ErrMin},
+		"D":   validate.Errors{validate.ErrRequired},
+	})
+
+	c.Assert(ok, Equals, false)
+	c.Assert(translations, DeepEquals, map[string][]errortranslator.Translation{
+		"B.1": {
+			{Code: "max", Message: "nil max default translate", UsedFallback: true, Err: validate.ErrMax},
+		},
+	})
+}
+
+func (s *TranslationSuite) TestTranslateDetailsMatchesTranslate(c *C) {
+	errorMap := validate.ErrorMap{
+		"A":   validate.Errors{validate.ErrRequired, validate.ErrMin},
+		"B.1": validate.Errors{validate.ErrMin, validate.ErrMax},
+		"D":   validate.Errors{validate.ErrRequired},
+	}
+
+	translated, ok := detailTranslations.Translate(errorMap)
+	c.Assert(ok, Equals, false)
+	c.Assert(translated, DeepEquals, map[string]string{
+		"A":   "A required translate, A default translate",
+		"B.1": "B min translate, nil max default translate",
+	})
+
+	translations, ok := detailTranslations.TranslateDetails(errorMap)
+	c.Assert(ok, Equals, false)
+	c.Assert(translations, HasLen, 2)
+	c.Assert(translations["A"], HasLen, 2)
+	c.Assert(translations["B.1"], HasLen, 2)
+}
+
+func (s *TranslationSuite) TestErrorTranslatorTranslateDetails(c *C) {
+	et := errortranslator.ErrorTranslator{
+		validate.ErrRequired: "required translate",
+	}
+
+	translations, ok := et.TranslateDetails(validate.Errors{validate.ErrRequired, validate.ErrMin, validate.ErrMax},
+		errortranslator.ErrorTranslator{validate.ErrMin: "min fallback translate"})
+
+	c.Assert(ok, Equals, true)
+	c.Assert(translations, DeepEquals, []errortranslator.Translation{
+		{Code: "required", Message: "required translate", Err: validate.ErrRequired},
+		{Code: "min", Message: "min fallback translate", UsedFallback: true, Err: validate.ErrMin},
+	})
+
+	translations, ok = et.TranslateFirstDetails(validate.Errors{validate.ErrMax})
+	c.Assert(ok, Equals, false)
+	c.Assert(translations, HasLen, 0)
+}
+
+func (s *TranslationSuite) TestLocaleTranslateDetails(c *C) {
+	lt := errortranslator.NewLocaleTranslator(language.English)
+	lt.AddLocale(language.English, errortranslator.New().
+		AddTranslation("name", validate.ErrRequired, "The name is required").
+		SetFallbackTranslation(validate.ErrMin, "The {field} is too short"))
+	lt.AddLocale(language.Dutch, errortranslator.New().
+		AddTranslation("name", validate.ErrRequired, "De naam is verplicht"))
+
+	translations, ok := lt.TranslateDetails(language.Dutch, validate.ErrorMap{
+		"name": validate.Errors{validate.ErrRequired, validate.ErrMin, validate.ErrMax},
+	}, errortranslator.ErrorTranslator{validate.ErrMax: "too long"})
+
+	c.Assert(ok, Equals, true)
+	c.Assert(translations, DeepEquals, map[string][]errortranslator.Translation{
+		"name": {
+			{Code: "required", Message: "De naam is verplicht", MatchedKey: "name", Err: validate.ErrRequired},
+			{Code: "min", Message: "The name is too short", UsedFallback: true, Err: validate.ErrMin},
+			{Code: "max", Message: "too long", UsedFallback: true, Err: validate.ErrMax},
+		},
+	})
+}
+
+func (s *TranslationSuite) TestWithUntranslated(c *C) {
+	errorMap := validate.ErrorMap{
+		"A": validate.Errors{validate.ErrMin, validate.ErrRequired, validate.ErrMin},
+		"C": validate.Errors{errUnregistered},
+	}
+	translations, _ := detailTranslations.TranslateDetails(errorMap)
+
+	result := errortranslator.WithUntranslated(errorMap, translations)
+
+	c.Assert(result, DeepEquals, map[string][]errortranslator.Translation{
+		"A": {
+			{Code: "default", Message: "A default translate", MatchedKey: "A", Err: validate.ErrMin},
+			{Code: "required", Message: "A required translate", MatchedKey: "A", Err: validate.ErrRequired},
+			{Code: "default", Message: "A default translate", MatchedKey: "A", Err: validate.ErrMin},
+		},
+		"C": {
+			{Err: errUnregistered},
+		},
+	})
+
+	//errors that cannot be compared with == are compared deeply
+	errorMap = validate.ErrorMap{"A": validate.Errors{wrappedError{multiError{validate.ErrRequired}}}}
+	translations, _ = detailTranslations.TranslateDetails(errorMap)
+
+	c.Assert(errortranslator.WithUntranslated(errorMap, translations)["A"], DeepEquals, []errortranslator.Translation{
+		{Code: "required", Message: "A required translate", MatchedKey: "A", Err: wrappedError{multiError{validate.ErrRequired}}},
+	})
+
+	//errors left out by the options are added without message
+	errorMap = validate.ErrorMap{"A": validate.Errors{validate.ErrRequired, validate.ErrRequired}}
+	translations, _ = detailTranslations.WithOptions(errortranslator.Options{Dedupe: true}).TranslateDetails(errorMap)
+
+	c.Assert(errortranslator.WithUntranslated(errorMap, translations)["A"], DeepEquals, []errortranslator.Translation{
+		{Code: "required", Message: "A required translate", MatchedKey: "A", Err: validate.ErrRequired},
+		{Code: "required", Err: validate.ErrRequired},
+	})
+}
@@ -0,0 +1,166 @@
+package errortranslator
+
+import (
+	"strings"
+
+	validate "github.com/mbict/go-validate"
+)
+
+// Options configures how errors are translated by a Translator or LocaleTranslator.
+type Options struct {
+	// Hierarchical enables the fallback to parent fields. When `address.street.number` has no translation for a error
+	// the fields `address.street` and `address` are tried before the fallback translations.
+	Hierarchical bool
+
+	// Separator separates the levels of a field path for the hierarchical fallback, the default is ".".
+	// A index in brackets is always a level of its own, the parent of `items[0]` is `items`.
+	Separator string
+
+	// Joiner joins the messages of a field, DefaultJoiner is used when no joiner is set.
+	Joiner Joiner
+
+	// Dedupe removes repeated messages of a field, the first occurrence is kept.
+	Dedupe bool
+
+	// Labels are the display names of the fields, used for the {label} placeholder. See Labels.
+	Labels Labels
+
+	// OnMissing is called for every error that has no translation, the error is left out of the result.
+	OnMissing func(Miss)
+
+	// SuppressDefault removes the default translations of a field when at least one error of the field has a specific
+	// translation. Prevents messages like "There is a unknown error, This field is required".
+	SuppressDefault bool
+}
+
+// filter removes the translations of a field the options exclude, the order of the translations is kept.
+func (opts Options) filter(translations []Translation) []Translation {
+	if !opts.Dedupe && !opts.SuppressDefault {
+		return translations
+	}
+
+	specific := false
+	for _, translation := range translations {
+		specific = specific || translation.Code != DefaultCode
+	}
+
+	result := translations[:0:0]
+	seen := make(map[string]bool, len(translations))
+	for _, translation := range translations {
+		if opts.SuppressDefault && specific && translation.Code == DefaultCode {
+			continue
+		}
+
+		if opts.Dedupe {
+			if seen[translation.Message] {
+				continue
+			}
+			seen[translation.Message] = true
+		}
+		result = append(result, translation)
+	}
+	return result
+}
+
+// Translator translates error maps with the translations of a FieldErrorTranslator and the options.
+type Translator struct {
+	Translations FieldErrorTranslator
+	Options      Options
+
+	//patterns is the pattern index of a snapshot, the translations of a snapshot do not change
+	patterns *patternIndex
+}
+
+// WithOptions returns a Translator that translates with the translations of the FieldErrorTranslator and the options.
+func (ft FieldErrorTranslator) WithOptions(opts Options) *Translator {
+	return &Translator{
+		Translations: ft,
+		Options:      opts,
+	}
+}
+
+// Translate works the same as FieldErrorTranslator.Translate but uses the options of the Translator.
+func (t *Translator) Translate(errorMap validate.ErrorMap, fallback ...ErrorTranslator) (map[string]string, bool) {
+	return t.Translations.translate(errorMap, false, fallback, t.Options, t.patternIndex())
+}
+
+// TranslateFirst works the same as FieldErrorTranslator.TranslateFirst but uses the options of the Translator.
+func (t *Translator) TranslateFirst(errorMap validate.ErrorMap, fallback ...ErrorTranslator) (map[string]string, bool) {
+	return t.Translations.translate(errorMap, true, fallback, t.Options, t.patternIndex())
+}
+
+// TranslateDetails works the same as FieldErrorTranslator.TranslateDetails but uses the options of the Translator.
+func (t *Translator) TranslateDetails(errorMap validate.ErrorMap, fallback ...ErrorTranslator) (map[string][]Translation, bool) {
+	return t.Translations.translateErrorMap(errorMap, false, fallback, t.Options, t.patternIndex())
+}
+
+// TranslateFirstDetails works the same as FieldErrorTranslator.TranslateFirstDetails but uses the options of the
+// Translator.
+func (t *Translator) TranslateFirstDetails(errorMap validate.ErrorMap, fallback ...ErrorTranslator) (map[string][]Translation, bool) {
+	return t.Translations.translateErrorMap(errorMap, true, fallback, t.Options, t.patternIndex())
+}
+
+// Joiner returns the joiner used to join the messages of a field, DefaultJoiner when no joiner is set.
+func (t *Translator) Joiner() Joiner {
+	return t.Options.joiner()
+}
+
+// Match returns the key that is used to translate the errors of a field. With the hierarchical fallback enabled the
+// parent fields are tried when the field itself has no match, e.g. `address` for the field `address.street`.
+func (t *Translator) Match(field string) (string, bool) {
+	keys := t.Translations.resolveKeys(field, t.Options, t.patternIndex())
+	if len(keys) == 0 {
+		return "", false
+	}
+	return keys[0], true
+}
+
+// patternIndex returns the pattern index of the snapshot, a new index for other translators
+func (t *Translator) patternIndex() patternIndex {
+	if t.patterns != nil {
+		return *t.patterns
+	}
+	return newPatternIndex(t.Translations)
+}
+
+// resolveKeys returns the keys to try for a field, the most specific first. With the hierarchical fallback enabled the
+// keys matching the parent fields follow the keys of the field itself.
+func (ft FieldErrorTranslator) resolveKeys(field string, opts Options, patterns patternIndex) []string {
+	keys := ft.matchKeys(field, patterns)
+	if !opts.Hierarchical {
+		return keys
+	}
+
+	separator := opts.Separator
+	if separator == "" {
+		separator = "."
+	}
+
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		seen[key] = true
+	}
+
+	for parent := parentField(field, separator); parent != ""; parent = parentField(parent, separator) {
+		for _, key := range ft.matchKeys(parent, patterns) {
+			if !seen[key] {
+				keys = append(keys, key)
+				seen[key] = true
+			}
+		}
+	}
+	return keys
+}
+
+// parentField returns the parent of a field path, a empty string is returned when the field has no parent.
+func parentField(field string, separator string) string {
+	i := strings.LastIndex(field, separator)
+	if bracket := strings.LastIndexByte(field, '['); bracket > i {
+		i = bracket
+	}
+
+	if i <= 0 {
+		return ""
+	}
+	return field[:i]
+}
@@ -0,0 +1,192 @@
+package errortranslator_test
+
+import (
+	errortranslator "github.com/mbict/go-errortranslator"
+	validate "github.com/mbict/go-validate"
+	"golang.org/x/text/language"
+	. "gopkg.in/check.v1"
+)
+
+type TranslatorSuite struct{}
+
+var _ = Suite(&TranslatorSuite{})
+
+var hierarchicalTranslations = errortranslator.FieldErrorTranslator{
+	"address": errortranslator.ErrorTranslator{
+		validate.ErrRequired: "address required translate",
+		nil:                  "address default translate",
+	},
+	"address.street": errortranslator.ErrorTranslator{
+		validate.ErrMin: "street min translate",
+	},
+	"items": errortranslator.ErrorTranslator{
+		validate.ErrMin: "items min translate",
+	},
+	"": errortranslator.ErrorTranslator{
+		validate.ErrMax: "nil max default translate",
+	},
+}
+
+func (s *TranslatorSuite) TestMatch(c *C) {
+	t := hierarchicalTranslations.WithOptions(errortranslator.Options{Hierarchical: true})
+
+	tests := []struct {
+		Field    string
+		Expected string
+		Ok       bool
+	}{
+		{Field: "address", Expected: "address", Ok: true},
+		{Field: "address.street", Expected: "address.street", Ok: true},
+		{Field: "address.street.number", Expected: "address.street", Ok: true},
+		{Field: "address.city", Expected: "address", Ok: true},
+		{Field: "items[0].price", Expected: "items", Ok: true},
+		{Field: "other.field", Expected: "", Ok: false},
+	}
+
+	for _, test := range tests {
+		key, ok := t.Match(test.Field)
+
+		c.Assert(ok, Equals, test.Ok, Commentf(test.Field))
+		c.Assert(key, Equals, test.Expected, Commentf(test.Field))
+	}
+
+	key, ok := hierarchicalTranslations.WithOptions(errortranslator.Options{}).Match("address.city")
+	c.Assert(key, Equals, "")
+	c.Assert(ok, Equals, false)
+}
+
+func (s *TranslatorSuite) TestMatchSeparator(c *C) {
+	t := errortranslator.FieldErrorTranslator{
+		"address": errortranslator.ErrorTranslator{},
+	}.WithOptions(errortranslator.Options{Hierarchical: true, Separator: "/"})
+
+	key, ok := t.Match("address/street")
+	c.Assert(key, Equals, "address")
+	c.Assert(ok, Equals, true)
+
+	_, ok = t.Match("address.street")
+	c.Assert(ok, Equals, false)
+}
+
+func (s *TranslatorSuite) TestTranslateHierarchical(c *C) {
+	t := hierarchicalTranslations.WithOptions(errortranslator.Options{Hierarchical: true})
+
+	translated, ok := t.Translate(validate.ErrorMap{
+		"address.street.number": validate.Errors{validate.ErrMin, validate.ErrRequired, validate.ErrMax},
+		"items[2].price":        validate.Errors{validate.ErrMin, validate.ErrMax},
+		"other":                 validate.Errors{validate.ErrMax, validate.ErrMin},
+	})
+
+	c.Assert(ok, Equals, true)
+	c.Assert(translated, DeepEquals, map[string]string{
+		"address.street.number": "street min translate, address required translate, address default translate",
+		"items[2].price":        "items min translate, nil max default translate",
+		"other":                 "nil max default translate",
+	})
+
+	translated, ok = t.TranslateFirst(validate.ErrorMap{
+		"address.city": validate.Errors{validate.ErrMax},
+	})
+
+	c.Assert(ok, Equals, true)
+	c.Assert(translated, DeepEquals, map[string]string{
+		"address.city": "address default translate",
+	})
+}
+
+func (s *TranslatorSuite) TestTranslateNotHierarchical(c *C) {
+	translated, ok := hierarchicalTranslations.WithOptions(errortranslator.Options{}).Translate(validate.ErrorMap{
+		"address.street.number": validate.Errors{validate.ErrMin, validate.ErrMax},
+	})
+
+	c.Assert(ok, Equals, true)
+	c.Assert(translated, DeepEquals, map[string]string{
+		"address.street.number": "nil max default translate",
+	})
+}
+
+func (s *TranslatorSuite) TestLocaleTranslatorHierarchical(c *C) {
+	lt := errortranslator.NewLocaleTranslator(language.English).
+		AddLocale(language.English, hierarchicalTranslations).
+		SetOptions(errortranslator.Options{Hierarchical: true})
+
+	translated, ok := lt.Translate(language.English, validate.ErrorMap{
+		"address.street.number": validate.Errors{validate.ErrRequired},
+	})
+
+	c.Assert(ok, Equals, true)
+	c.Assert(translated, DeepEquals, map[string]string{
+		"address.street.number": "address required translate",
+	})
+}
+
+var repeatedTranslations = errortranslator.FieldErrorTranslator{
+	"A": errortranslator.ErrorTranslator{
+		validate.ErrRequired: "required translate",
+	},
+	"": errortranslator.ErrorTranslator{
+		nil: "nil default translate",
+	},
+}
+
+func (s *TranslatorSuite) TestDedupe(c *C) {
+	errorMap := validate.ErrorMap{
+		"A": validate.Errors{validate.ErrMin, validate.ErrRequired, validate.ErrMax, validate.ErrRequired},
+	}
+
+	result, ok := repeatedTranslations.Translate(errorMap)
+	c.Assert(ok, Equals, true)
+	c.Assert(result, DeepEquals, map[string]string{
+		"A": "nil default translate, required translate, nil default translate, required translate",
+	})
+
+	result, ok = repeatedTranslations.WithOptions(errortranslator.Options{Dedupe: true}).Translate(errorMap)
+	c.Assert(ok, Equals, true)
+	c.Assert(result, DeepEquals, map[string]string{
+		"A": "nil default translate, required translate",
+	})
+}
+
+func (s *TranslatorSuite) TestSuppressDefault(c *C) {
+	t := repeatedTranslations.WithOptions(errortranslator.Options{SuppressDefault: true})
+
+	result, ok := t.Translate(validate.ErrorMap{
+		"A": validate.Errors{validate.ErrMin, validate.ErrRequired, validate.ErrMax},
+		"B": validate.Errors{validate.ErrMin, validate.ErrMax},
+	})
+
+	c.Assert(ok, Equals, true)
+	c.Assert(result, DeepEquals, map[string]string{
+		"A": "required translate",
+		"B": "nil default translate, nil default translate",
+	})
+
+	t.Options.Dedupe = true
+	result, ok = t.Translate(validate.ErrorMap{
+		"B": validate.Errors{validate.ErrMin, validate.ErrMax},
+	})
+
+	c.Assert(ok, Equals, true)
+	c.Assert(result, DeepEquals, map[string]string{"B": "nil default translate"})
+}
+
+func (s *TranslatorSuite) TestSuppressDefaultFirst(c *C) {
+	errorMap := validate.ErrorMap{
+		"A": validate.Errors{validate.ErrMin, validate.ErrRequired},
+		"B": validate.Errors{validate.ErrMin, validate.ErrRequired},
+	}
+
+	result, ok := repeatedTranslations.TranslateFirst(errorMap)
+	c.Assert(ok, Equals, true)
+	c.Assert(result, DeepEquals, map[string]string{
+		"A": "nil default translate",
+		"B": "nil default translate",
+	})
+
+	result, ok = repeatedTranslations.WithOptions(errortranslator.Options{SuppressDefault: true}).TranslateFirst(errorMap)
+	c.Assert(ok, Equals, true)
+	c.Assert(result, DeepEquals, map[string]string{
+		"A": "required translate",
+		"B": "nil default translate",
+	})
+}